@@ -0,0 +1,26 @@
+package assert
+
+import "testing"
+
+// allocsRuns is the number of times MaxAllocs runs the function under test.
+const allocsRuns = 100
+
+// MaxAllocs validates that f allocates no more than n times per call on
+// average, as measured by [testing.AllocsPerRun]. This makes it possible to
+// lock in allocation guarantees for performance-sensitive code in regular
+// tests, e.g.:
+//
+//	assert.MaxAllocs(t, "encode", 0, func() {
+//		enc.Encode(buf, msg)
+//	})
+//
+// f is called once to warm up and then 100 more times. Allocation counts are
+// affected by instrumentation such as the race detector and coverage, so
+// tests using MaxAllocs may need to be skipped when those are enabled.
+func MaxAllocs(t T, label string, n int, f func()) Result {
+	t.Helper()
+	if got := testing.AllocsPerRun(allocsRuns, f); got > float64(n) {
+		return fail(t, label, "Expected %s to allocate at most %d times per run, but got %v.", label, wantValue(n), gotValue(got))
+	}
+	return pass(t)
+}
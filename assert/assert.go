@@ -2,29 +2,134 @@
 package assert
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"slices"
 	"strings"
 )
 
+// Response is the set of types accepted by the HTTP assertions in this
+// package. A *httptest.ResponseRecorder is converted to an *http.Response by
+// calling its Result method, so handler tests can pass the recorder directly.
+type Response interface {
+	*http.Response | *httptest.ResponseRecorder
+}
+
 // Result represents the result of an assertion nad is returned by all of the
 // assertion functions in this package.
 type Result struct {
 	t      T
+	label  string
 	failed bool
 }
 
+// BodyContains validates that the body of the provided response contains the
+// desired string. The body is read in full and then replaced with a fresh
+// reader over the same bytes, so it can still be read or decoded by subsequent
+// assertions.
+func BodyContains[R Response](t T, r R, want string) Result {
+	t.Helper()
+	resp := response(r)
+	body, err := readBody(resp)
+	if err != nil {
+		return fail(t, "body", "Unexpected error reading body: %v.", err)
+	}
+	if got := string(body); !strings.Contains(got, want) {
+		return fail(t, "body", "Expected body to contain %q, but got %q.", wantValue(want), gotValue(got))
+	}
+	return pass(t)
+}
+
+// Condition validates that the provided predicate returns true. This is
+// useful for one-off checks that don't fit any of the other assertions, e.g.:
+//
+//	assert.Condition(t, "sorted users", func() bool {
+//		return slices.IsSortedFunc(users, byName)
+//	})
+func Condition(t T, label string, f func() bool) Result {
+	t.Helper()
+	if !f() {
+		return fail(t, label, "Expected %s to hold, but it didn't.", label)
+	}
+	return pass(t)
+}
+
+// Conditionf is like Condition, but the label is built from a format string
+// and arguments, e.g.:
+//
+//	for _, n := range primes {
+//		assert.Conditionf(t, func() bool { return isPrime(n) }, "%d is prime", n)
+//	}
+func Conditionf(t T, f func() bool, format string, args ...any) Result {
+	t.Helper()
+	return Condition(t, fmt.Sprintf(format, args...), f)
+}
+
 // ContentType validates that the value of the `Content-Type` header of the
-// provided response matches the desired value.
-func ContentType(t T, resp *http.Response, want string) Result {
+// provided response matches the desired media type. Both values are parsed
+// with [mime.ParseMediaType], so the comparison ignores case and whitespace,
+// and any parameters in want must also be present in the header with the same
+// values. Parameters that aren't in want are ignored, so the following
+// assertion succeeds for a response with a Content-Type of
+// "application/json; charset=utf-8":
+//
+//	assert.ContentType(t, resp, "application/json")
+//
+// while this one also requires the charset to match:
+//
+//	assert.ContentType(t, resp, "application/json; charset=utf-8")
+//
+// Values of the charset parameter are compared case-insensitively.
+func ContentType[R Response](t T, r R, want string) Result {
 	t.Helper()
+	resp := response(r)
 	got := resp.Header.Get("Content-Type")
-	if got != want {
-		t.Errorf("Expected content type to be %s, but got %s.", want, got)
-		return Result{t: t, failed: true}
+
+	wantType, wantParams, err := mime.ParseMediaType(want)
+	if err != nil {
+		return fail(t, "content type", "Unexpected error parsing wanted content type %s: %v.", want, err)
 	}
-	return Result{t: t, failed: false}
+	gotType, gotParams, err := mime.ParseMediaType(got)
+	if err != nil || gotType != wantType {
+		return fail(t, "content type", "Expected content type to be %s, but got %s.", wantValue(want), gotValue(got))
+	}
+	for _, name := range slices.Sorted(maps.Keys(wantParams)) {
+		wantParam, gotParam := wantParams[name], gotParams[name]
+		if gotParam == wantParam || (name == "charset" && strings.EqualFold(gotParam, wantParam)) {
+			continue
+		}
+		return fail(t, "content type",
+			"Expected content type to have %s=%s, but got %s.",
+			name, wantValue(wantParam), gotValue(got),
+		)
+	}
+	return pass(t)
+}
+
+// Cookie validates that the provided response sets a cookie with the given name
+// and returns the parsed cookie so that follow-up assertions can be made on its
+// attributes, e.g.:
+//
+//	cookie, _ := assert.Cookie(t, resp, "session")
+//	assert.True(t, "http only", cookie.HttpOnly)
+//
+// If the cookie isn't present, the returned cookie is nil. If the response sets
+// the same cookie more than once, the first occurrence is returned.
+func Cookie[R Response](t T, r R, name string) (*http.Cookie, Result) {
+	t.Helper()
+	resp := response(r)
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == name {
+			return cookie, pass(t)
+		}
+	}
+	return nil, fail(t, "cookie "+name, "Expected cookie %s to be set, but it wasn't.", name)
 }
 
 // DeepEqual validates that two values are "deeply equal" according to the same
@@ -57,10 +162,9 @@ func ContentType(t T, resp *http.Response, want string) Result {
 func DeepEqual(t T, label string, want, got any) Result {
 	t.Helper()
 	if !reflect.DeepEqual(got, want) {
-		t.Errorf("Expected %s to be equal, but they weren't.", label)
-		return Result{t: t, failed: true}
+		return failNotEqual(t, label, want, got, "")
 	}
-	return Result{t: t, failed: false}
+	return pass(t)
 }
 
 // Equal validates that two values are the same.
@@ -93,10 +197,20 @@ func DeepEqual(t T, label string, want, got any) Result {
 func Equal[C comparable](t T, label string, want, got C) Result {
 	t.Helper()
 	if got != want {
-		t.Errorf("Expected %s to be %v, but got %v.", label, want, got)
-		return Result{t: t, failed: true}
+		return fail(t, label, "Expected %s to be %v, but got %v.", label, wantValue(want), gotValue(got))
 	}
-	return Result{t: t, failed: false}
+	return pass(t)
+}
+
+// EqualFold validates that two strings are equal under simple Unicode
+// case-folding, as determined by [strings.EqualFold]. This is useful for
+// values such as header values and identifiers whose case is insignificant.
+func EqualFold(t T, label, want, got string) Result {
+	t.Helper()
+	if !strings.EqualFold(want, got) {
+		return fail(t, label, "Expected %s to be %q, but got %q (compared case-insensitively).", label, wantValue(want), gotValue(got))
+	}
+	return pass(t)
 }
 
 // Error validates that the provided error is not nil and contains the desired
@@ -107,17 +221,15 @@ func Equal[C comparable](t T, label string, want, got C) Result {
 func Error(t T, err error, want string) Result {
 	t.Helper()
 	if err == nil {
-		t.Errorf("Expected error to not be nil, but it was.")
-		return Result{t: t, failed: true}
+		return fail(t, "error", "Expected error to not be nil, but it was.")
 	}
 
 	got := err.Error()
 	if !strings.Contains(got, want) {
-		t.Errorf("Expected error to contain %q, but got %q.", want, got)
-		return Result{t: t, failed: true}
+		return fail(t, "error", "Expected error to contain %q, but got %q.", wantValue(want), gotValue(got))
 	}
 
-	return Result{t: t, failed: false}
+	return pass(t)
 }
 
 // False validates that the provided value is false.
@@ -126,26 +238,110 @@ func False(t T, label string, got bool) Result {
 	return Equal(t, label, false, got)
 }
 
+// Header validates that the value of the named header of the provided response
+// matches the desired value. If the header has multiple values, only the first
+// is considered.
+func Header[R Response](t T, r R, key, want string) Result {
+	t.Helper()
+	resp := response(r)
+	got := resp.Header.Get(key)
+	if got != want {
+		return fail(t, "header "+key, "Expected header %s to be %s, but got %s.", key, wantValue(want), gotValue(got))
+	}
+	return pass(t)
+}
+
+// HeaderAbsent validates that the named header is not present in the provided
+// response. This is useful for verifying that internal or security-sensitive
+// headers such as X-Powered-By aren't exposed.
+func HeaderAbsent[R Response](t T, r R, key string) Result {
+	t.Helper()
+	resp := response(r)
+	if values, ok := resp.Header[http.CanonicalHeaderKey(key)]; ok {
+		return fail(t, "header "+key, "Expected header %s to be absent, but got %q.", key, gotValue(strings.Join(values, ", ")))
+	}
+	return pass(t)
+}
+
+// HeaderPresent validates that the named header is present in the provided
+// response, regardless of its value.
+func HeaderPresent[R Response](t T, r R, key string) Result {
+	t.Helper()
+	resp := response(r)
+	if _, ok := resp.Header[http.CanonicalHeaderKey(key)]; !ok {
+		return fail(t, "header "+key, "Expected header %s to be present, but it wasn't.", key)
+	}
+	return pass(t)
+}
+
 // NotBlank validates that the provided string is not the blank string. Leading
 // and trailing spaces are removed from got before validation.
 func NotBlank(t T, label string, got string) Result {
 	t.Helper()
 	got = strings.TrimSpace(got)
 	if got == "" {
-		t.Errorf("Expected %s to not be blank, but it was.", label)
-		return Result{t: t, failed: true}
+		return fail(t, label, "Expected %s to not be blank, but it was.", label)
 	}
-	return Result{t: t, failed: false}
+	return pass(t)
+}
+
+// NotDeepEqual validates that two values are not "deeply equal" according to
+// the rules of [reflect.DeepEqual]. It is the inverse of [DeepEqual].
+func NotDeepEqual(t T, label string, unwanted, got any) Result {
+	t.Helper()
+	if reflect.DeepEqual(got, unwanted) {
+		return fail(t, label, "Expected %s to not be equal, but they were.", label)
+	}
+	return pass(t)
+}
+
+// NotEqual validates that two values are different. It is the inverse of
+// [Equal] and is subject to the same caveats regarding pointers.
+func NotEqual[C comparable](t T, label string, unwanted, got C) Result {
+	t.Helper()
+	if got == unwanted {
+		return fail(t, label, "Expected %s to not be %v, but it was.", label, gotValue(got))
+	}
+	return pass(t)
 }
 
 // OK validates that the provided err is nil.
 func OK(t T, err error) Result {
 	t.Helper()
 	if err != nil {
-		t.Errorf("Unexpected error: %v.", err)
-		return Result{t: t, failed: true}
+		return fail(t, "error", "Unexpected error: %v.", err)
 	}
-	return Result{t: t, failed: false}
+	return pass(t)
+}
+
+// RedirectsTo validates that the provided response is a redirect (has a 3xx
+// status code) and that its `Location` header matches the desired URL exactly.
+func RedirectsTo[R Response](t T, r R, want string) Result {
+	t.Helper()
+	resp := response(r)
+	return redirectsTo(t, resp, want, func(got string) bool { return got == want })
+}
+
+// RedirectsToPrefix validates that the provided response is a redirect (has a
+// 3xx status code) and that its `Location` header starts with the desired
+// prefix. This is useful when the redirect includes query parameters such as
+// state tokens that vary between requests.
+func RedirectsToPrefix[R Response](t T, r R, prefix string) Result {
+	t.Helper()
+	resp := response(r)
+	return redirectsTo(t, resp, prefix, func(got string) bool { return strings.HasPrefix(got, prefix) })
+}
+
+func redirectsTo(t T, resp *http.Response, want string, match func(got string) bool) Result {
+	t.Helper()
+	if resp.StatusCode < 300 || resp.StatusCode > 399 {
+		return fail(t, "redirect", "Expected status code to be a redirect, but got %d.", resp.StatusCode)
+	}
+	got := resp.Header.Get("Location")
+	if !match(got) {
+		return fail(t, "redirect", "Expected redirect to %s, but got %s.", wantValue(want), gotValue(got))
+	}
+	return pass(t)
 }
 
 // ShouldPanic validates that calling f results in a panic. This can be useful
@@ -154,14 +350,12 @@ func OK(t T, err error) Result {
 // package).
 func ShouldPanic(t T, f func()) (result Result) {
 	t.Helper()
-	result = Result{t: t}
 	defer func() {
-		r := recover()
-		if r == nil {
-			t.Errorf("Expected function to panic, but it didn't.")
-			result.failed = true
+		if r := recover(); r == nil {
+			result = fail(t, "function", "Expected function to panic, but it didn't.")
 			return
 		}
+		result = pass(t)
 	}()
 	f()
 	return
@@ -174,23 +368,22 @@ func SliceEqual[S ~[]E, E comparable](t T, label string, want, got S) Result {
 	t.Helper()
 
 	if !slices.Equal(got, want) {
-		t.Errorf("Expected %s to be %v, but got %v.", label, want, got)
-		return Result{t: t, failed: true}
+		return fail(t, label, "Expected %s to be %v, but got %v.", label, wantValue(want), gotValue(got))
 	}
 
-	return Result{t: t, failed: false}
+	return pass(t)
 }
 
 // StatusCode validates that the status code of the provided response matches
 // the desired value.
-func StatusCode(t T, want int, resp *http.Response) Result {
+func StatusCode[R Response](t T, want int, r R) Result {
 	t.Helper()
+	resp := response(r)
 	got := resp.StatusCode
 	if got != want {
-		t.Errorf("Expected status code to be %d, but got %d.", want, got)
-		return Result{t: t, failed: true}
+		return fail(t, "status code", "Expected status code to be %d, but got %d.", wantValue(want), gotValue(got))
 	}
-	return Result{t: t, failed: false}
+	return pass(t)
 }
 
 // True validates that the provided value is true.
@@ -199,6 +392,55 @@ func True(t T, label string, got bool) Result {
 	return Equal(t, label, true, got)
 }
 
+// Fail reports a failed assertion with the given label and message and returns
+// the corresponding Result. Fail and Pass are the building blocks for
+// assertions defined outside of this package, and reporting through them
+// ensures that custom assertions work with Collector, OnFailure, and
+// TrackStats in the same way as the assertions in this package.
+func Fail(t T, label, format string, args ...any) Result {
+	t.Helper()
+	return fail(t, label, format, args...)
+}
+
+// Pass returns the Result for a successful assertion. See Fail for details.
+func Pass(t T) Result {
+	t.Helper()
+	return pass(t)
+}
+
+// All combines results into a single Result that is only OK if every one of
+// the results is OK. This makes it possible to stop the test after a group of
+// related checks have all been made, e.g.:
+//
+//	assert.All(
+//		assert.Equal(t, "name", "Bender", robot.Name),
+//		assert.Equal(t, "model", "Bending Unit 22", robot.Model),
+//	).Fatal()
+//
+// Every assertion has already reported its own failure by the time All is
+// called, so All doesn't report anything itself. Calling All with no results
+// returns a Result that is OK.
+func All(results ...Result) Result {
+	var combined Result
+	for _, r := range results {
+		combined = combined.And(r)
+	}
+	return combined
+}
+
+// And combines r with other into a single Result that is only OK if both are
+// OK. See All for details.
+func (r Result) And(other Result) Result {
+	switch {
+	case r.failed || other.t == nil:
+		return r
+	case other.failed || r.t == nil:
+		return other
+	default:
+		return r
+	}
+}
+
 // Fatal causes the test suite to immediately fail if the current result
 // corresponds to a failed assertion. You can chain this off of any of the
 // assertion functions and is most often useful for exiting due to an unexpected
@@ -206,16 +448,42 @@ func True(t T, label string, got bool) Result {
 //
 //	assert.OK(t, err).Fatal()
 func (r Result) Fatal() {
-	r.t.Helper()
 	if r.failed {
+		r.t.Helper()
 		r.t.FailNow()
 	}
 }
 
-// OK returns true if the current result corresponds to a failed assertion or
-// false otherwise.
-func (r Result) OK() bool {
+// Msgf adds context to a failed assertion by appending the formatted message
+// to the standard failure output. This is most useful in table tests where the
+// standard output doesn't identify which case failed, e.g.:
+//
+//	assert.Equal(t, "sum", tc.want, got).Msgf("case %d: %s", i, tc.name)
+//
+// Msgf does nothing if the current result corresponds to a successful
+// assertion and returns the Result unchanged so that it can be chained with
+// Fatal.
+func (r Result) Msgf(format string, args ...any) Result {
+	if !r.failed {
+		return r
+	}
 	r.t.Helper()
+	msg := fmt.Sprintf(format, args...)
+	if a, ok := r.t.(failureAnnotator); ok {
+		a.annotateFailure(msg)
+	} else {
+		r.t.Log(msg)
+	}
+	return r
+}
+
+// OK returns true if the current result corresponds to a successful assertion
+// or false otherwise.
+func (r Result) OK() bool {
+	// The zero Result, e.g. from calling All with no results, has no T.
+	if r.t != nil {
+		r.t.Helper()
+	}
 	return !r.failed
 }
 
@@ -232,4 +500,75 @@ type T interface {
 	Errorf(format string, args ...any)
 	FailNow()
 	Log(args ...any)
+	Skip(args ...any)
+}
+
+// response returns the *http.Response represented by r.
+func response[R Response](r R) *http.Response {
+	switch r := any(r).(type) {
+	case *httptest.ResponseRecorder:
+		return r.Result()
+	case *http.Response:
+		return r
+	}
+	panic(fmt.Sprintf("assert: unsupported response type %T", r))
+}
+
+// readBody reads the full body of resp and replaces it with a new reader over
+// the same content so that it can be read again.
+func readBody(resp *http.Response) ([]byte, error) {
+	if resp.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// failureRecorder is implemented by T values that want to capture failures
+// themselves rather than having them reported immediately via Errorf.
+type failureRecorder interface {
+	recordFailure(label, msg string)
+}
+
+// failureAnnotator is implemented by T values that record failures and want
+// to attach additional context to the most recently recorded one.
+type failureAnnotator interface {
+	annotateFailure(msg string)
+}
+
+// passRecorder is implemented by T values that want to be notified of
+// successful assertions.
+type passRecorder interface {
+	recordPass()
+}
+
+// fail reports a failed assertion to t and returns the corresponding Result.
+// All assertions should report failures through this function so that
+// failures are handled consistently.
+func fail(t T, label, format string, args ...any) Result {
+	t.Helper()
+	msg := fmt.Sprintf(format, args...)
+	if r, ok := t.(failureRecorder); ok {
+		r.recordFailure(label, msg)
+	} else {
+		t.Errorf("%s", msg)
+	}
+	recordStats(t, true)
+	runFailureHooks(t, label)
+	return Result{t: t, label: label, failed: true}
+}
+
+// pass returns the Result for a successful assertion.
+func pass(t T) Result {
+	t.Helper()
+	if r, ok := t.(passRecorder); ok {
+		r.recordPass()
+	}
+	recordStats(t, false)
+	return Result{t: t, failed: false}
 }
@@ -1,23 +1,233 @@
 package assert_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"math/rand/v2"
+	"mime/multipart"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"testing/fstest"
+	"time"
 
 	"github.com/haleyrc/lib/assert"
+	"github.com/haleyrc/lib/log"
 )
 
+func ExampleAll() {
+	name, model := "Bender", "Bending Unit 23"
+
+	ok := assert.All(
+		assert.Equal(t, "name", "Bender", name),
+		assert.Equal(t, "model", "Bending Unit 22", model),
+	).OK()
+	fmt.Println(ok)
+
+	// Output: Expected model to be Bending Unit 22, but got Bending Unit 23.
+	// false
+}
+
+func ExampleBigEqual() {
+	assert.BigEqual(t, "balance", big.NewInt(1_000_000), new(big.Int).Mul(big.NewInt(1000), big.NewInt(1000)))
+	assert.BigEqual(t, "balance", big.NewInt(1_000_000), big.NewInt(999_999))
+
+	// Precision doesn't matter as long as the values are the same.
+	assert.BigEqual(t, "rate", big.NewFloat(0.5), new(big.Float).SetPrec(200).SetFloat64(0.5))
+	assert.BigEqual(t, "rate", big.NewFloat(0.5), big.NewFloat(0.25))
+
+	assert.BigEqual(t, "ratio", big.NewRat(1, 3), big.NewRat(2, 6))
+	assert.BigEqual(t, "ratio", big.NewRat(1, 3), nil)
+
+	// Output: Expected balance to be 1000000, but got 999999.
+	// Expected rate to be 0.5, but got 0.25.
+	// Expected ratio to be 1/3, but got <nil>.
+}
+
+func ExampleBodyContains() {
+	resp := new(http.Response)
+	resp.Body = io.NopCloser(strings.NewReader(`{"name":"Bender"}`))
+
+	assert.BodyContains(t, resp, "Bender")
+	assert.BodyContains(t, resp, "Flexo")
+
+	// The body is still available after the assertions.
+	var robot struct{ Name string }
+	assert.OK(t, json.NewDecoder(resp.Body).Decode(&robot))
+	assert.Equal(t, "name", "Bender", robot.Name)
+
+	// Output: Expected body to contain "Flexo", but got "{\"name\":\"Bender\"}".
+}
+
+func ExampleBodyGolden() {
+	respond := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+		return w
+	}
+
+	// Since the content type is JSON, differences in formatting and key order
+	// are ignored.
+	assert.BodyGolden(t, respond(`{"model":"Bending Unit 22","name":"Bender"}`), "testdata/robot.json")
+	assert.BodyGolden(t, respond(`{"name":"Flexo","model":"Bending Unit 22"}`), "testdata/robot.json")
+
+	// Output: Expected line 3 of body to be "  \"name\": \"Bender\"", but got "  \"name\": \"Flexo\"". Run the tests with UPDATE_GOLDEN=1 to update testdata/robot.json.
+}
+
+func ExampleClosed() {
+	done := make(chan struct{})
+	assert.Closed(t, "done", done, 10*time.Millisecond)
+
+	close(done)
+	assert.Closed(t, "done", done, 10*time.Millisecond)
+
+	results := make(chan int, 1)
+	results <- 42
+	assert.Closed(t, "results", results, 10*time.Millisecond)
+
+	// Output: Expected done to be closed within 10ms, but it wasn't.
+	// Expected results to be closed, but received 42.
+}
+
+func ExampleCompletesWithin() {
+	assert.CompletesWithin(t, "shutdown", time.Second, func() {
+		time.Sleep(10 * time.Millisecond)
+	})
+	assert.CompletesWithin(t, "shutdown", time.Second, func() {
+		panic("unexpected state")
+	})
+
+	// Output: Expected shutdown to complete, but it panicked: unexpected state.
+}
+
+func ExampleCondition() {
+	names := []string{"Bender", "Flexo", "Amy"}
+
+	assert.Condition(t, "sort order", func() bool {
+		return slices.IsSorted(names)
+	})
+
+	// Output: Expected sort order to hold, but it didn't.
+}
+
+func ExampleConditionf() {
+	for _, n := range []int{2, 4, 6, 9} {
+		assert.Conditionf(t, func() bool { return n%2 == 0 }, "%d is even", n)
+	}
+
+	// Output: Expected 9 is even to hold, but it didn't.
+}
+
 func ExampleContentType() {
 	resp := new(http.Response)
 
 	header := http.Header{}
-	header.Set("Content-Type", "application/json")
+	header.Set("Content-Type", "application/json; charset=UTF-8")
 	resp.Header = header
 
 	assert.ContentType(t, resp, "application/json")
+	assert.ContentType(t, resp, "Application/JSON; charset=utf-8")
 	assert.ContentType(t, resp, "application/xml")
+	assert.ContentType(t, resp, "application/json; charset=iso-8859-1")
+
+	// Output: Expected content type to be application/xml, but got application/json; charset=UTF-8.
+	// Expected content type to have charset=iso-8859-1, but got application/json; charset=UTF-8.
+}
+
+func ExampleContext() {
+	ctx := assert.Context(t)
+
+	assert.ContextAlive(t, ctx)
+
+	// Output:
+}
 
-	// Output: Expected content type to be application/xml, but got application/json.
+func ExampleContextAlive() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	assert.ContextAlive(t, ctx)
+	cancel()
+	assert.ContextAlive(t, ctx)
+
+	// Output: Expected context to be alive, but got context canceled.
+}
+
+func ExampleContextCanceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	assert.ContextCanceled(t, ctx)
+	cancel()
+	assert.ContextCanceled(t, ctx)
+
+	// Output: Expected context to be done with context canceled, but it wasn't done.
+}
+
+func ExampleContextDeadlineExceeded() {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	assert.ContextDeadlineExceeded(t, ctx)
+	assert.ContextCanceled(t, ctx)
+
+	// Output: Expected context error to be context canceled, but got context deadline exceeded.
+}
+
+func ExampleCookie() {
+	resp := new(http.Response)
+
+	header := http.Header{}
+	header.Add("Set-Cookie", "session=abc123; HttpOnly; SameSite=Strict")
+	resp.Header = header
+
+	cookie, result := assert.Cookie(t, resp, "session")
+	if result.OK() {
+		assert.Equal(t, "value", "abc123", cookie.Value)
+		assert.True(t, "http only", cookie.HttpOnly)
+		assert.Equal(t, "same site", http.SameSiteLaxMode, cookie.SameSite)
+	}
+
+	assert.Cookie(t, resp, "csrf")
+
+	// Output: Expected same site to be 2, but got 3.
+	// Expected cookie csrf to be set, but it wasn't.
+}
+
+func ExampleCSVEqual() {
+	want := "id,name\n1,Bender\n2,Flexo\n"
+
+	assert.CSVEqual(t, "report", want, "id,name\n1,\"Bender\"\n2,Flexo\n")
+	assert.CSVEqual(t, "report", want, "id,name\n1,Bender\n2,Flexo")
+	assert.CSVEqual(t, "report", want, "id,name\n1,Bender\n2,Flexo", assert.CSVIgnoreTrailingNewline())
+	assert.CSVEqual(t, "report", want, "id,name\n1,Bender\n2,Hermes\n")
+	assert.CSVEqual(t, "report", want, "id,name\n1,Bender\n2,Hermes\n", assert.CSVHeader())
+
+	// Output: Expected report to end with a newline, but it didn't.
+	// Expected record 3, column 2 of report to be "Flexo", but got "Hermes".
+	// Expected record 3, column "name" of report to be "Flexo", but got "Hermes".
+}
+
+func ExampleCSVIgnoreColumnOrder() {
+	want := "id,name\n1,Bender\n2,Flexo\n"
+
+	assert.CSVEqual(t, "report", want, "name,id\nBender,1\nFlexo,2\n", assert.CSVIgnoreColumnOrder())
+	assert.CSVEqual(t, "report", want, "name,id\nBender,1\nFlexo,3\n", assert.CSVIgnoreColumnOrder())
+	assert.CSVEqual(t, "report", want, "name,uid\nBender,1\nFlexo,2\n", assert.CSVIgnoreColumnOrder())
+
+	// Output: Expected record 3, column "id" of report to be "2", but got "3".
+	// Expected report to have header ["id" "name"], but got ["name" "uid"].
 }
 
 func ExampleDeepEqual() {
@@ -40,8 +250,79 @@ func ExampleDeepEqual() {
 	assert.DeepEqual(t, "composers", &bach1, &bach2)
 	assert.DeepEqual(t, "composers", &bach1, &shostakovich)
 
-	// Output: Expected composers to be equal, but they weren't.
-	// Expected composers to be equal, but they weren't.
+	// Output: Expected composers to be {J.S. Bach}, but got {D. Shostakovich}.
+	// Expected composers to be &{J.S. Bach}, but got &{D. Shostakovich}.
+}
+
+func ExampleDeepEqualIgnoring() {
+	type Item struct {
+		ID  int
+		SKU string
+	}
+	type Order struct {
+		ID        int
+		CreatedAt time.Time
+		Items     []Item
+	}
+
+	want := Order{Items: []Item{{SKU: "BENDER-22"}}}
+	got := Order{
+		ID:        42,
+		CreatedAt: time.Date(2024, time.February, 1, 12, 1, 32, 0, time.UTC),
+		Items:     []Item{{ID: 1, SKU: "BENDER-22"}},
+	}
+
+	assert.DeepEqualIgnoring(t, "order", want, got, "ID", "CreatedAt", "Items.ID")
+	assert.DeepEqualIgnoring(t, "order", want, got, "ID", "CreatedAt")
+
+	// Output: Expected order to be {0 0001-01-01 00:00:00 +0000 UTC [{0 BENDER-22}]}, but got {42 2024-02-01 12:01:32 +0000 UTC [{1 BENDER-22}]} (ignoring ID, CreatedAt).
+}
+
+func ExampleDeepEqualWith() {
+	type Event struct {
+		Name string
+		At   time.Time
+	}
+
+	utc := time.Date(2024, 2, 1, 17, 1, 32, 0, time.UTC)
+	est := utc.In(time.FixedZone("EST", -5*60*60))
+
+	// The two times represent the same instant, but have different locations, so
+	// they are only considered equal when compared using time.Time.Equal.
+	assert.DeepEqual(t, "events", Event{"launch", utc}, Event{"launch", est})
+	assert.DeepEqualWith(t, "events", Event{"launch", utc}, Event{"launch", est},
+		assert.Using(time.Time.Equal),
+	)
+
+	// Output: Expected events to be {launch 2024-02-01 17:01:32 +0000 UTC}, but got {launch 2024-02-01 12:01:32 -0500 EST}.
+}
+
+func ExampleDirEqual() {
+	want := fstest.MapFS{
+		"main.go":        {Data: []byte("package main\n")},
+		"models/user.go": {Data: []byte("package models\n")},
+		"README.md":      {Data: []byte("# Generated\n")},
+	}
+	got := fstest.MapFS{
+		"main.go":         {Data: []byte("package main\n")},
+		"models/user.go":  {Data: []byte("package model\n")},
+		"models/robot.go": {Data: []byte("package models\n")},
+	}
+
+	assert.DirEqual(t, want, want)
+	assert.DirEqual(t, want, got)
+
+	// Output: Expected directories to be equal, but they weren't:
+	// 	missing: README.md
+	// 	different: models/user.go
+	// 	extra: models/robot.go
+}
+
+func ExampleDurationWithin() {
+	assert.DurationWithin(t, "run time", 100*time.Millisecond, 112*time.Millisecond, 20*time.Millisecond)
+	assert.DurationWithin(t, "run time", 100*time.Millisecond, 75*time.Millisecond, 20*time.Millisecond)
+
+	// Output: Expected run time to be within 20ms of 100ms, but got 75ms (delta -25ms).
 }
 
 func ExampleEqual_complexTypes() {
@@ -78,6 +359,42 @@ func ExampleEqual_simpleTypes() {
 	// Expected string to be Hello, World, but got Goodbye, World.
 }
 
+func ExampleEqualExportedFields() {
+	type Account struct {
+		ID      string
+		Balance int
+
+		cache map[string]int
+	}
+
+	want := &Account{ID: "acct-1", Balance: 100}
+	got := &Account{ID: "acct-1", Balance: 100, cache: map[string]int{"hits": 3}}
+
+	assert.EqualExportedFields(t, "account", want, got)
+
+	got.Balance = 50
+	assert.EqualExportedFields(t, "account", want, got)
+
+	// Output: Expected account to be &{acct-1 100 map[]}, but got &{acct-1 50 map[hits:3]}.
+}
+
+func ExampleEqualFold() {
+	assert.EqualFold(t, "scheme", "Bearer", "bearer")
+	assert.EqualFold(t, "scheme", "Bearer", "Basic")
+
+	// Output: Expected scheme to be "Bearer", but got "Basic" (compared case-insensitively).
+}
+
+func ExampleEqualFunc() {
+	utc := time.Date(2024, 2, 1, 17, 1, 32, 0, time.UTC)
+	est := utc.In(time.FixedZone("EST", -5*60*60))
+
+	assert.EqualFunc(t, "instant", utc, est, time.Time.Equal)
+	assert.EqualFunc(t, "instant", utc, est.Add(time.Hour), time.Time.Equal)
+
+	// Output: Expected instant to be 2024-02-01 17:01:32 +0000 UTC, but got 2024-02-01 13:01:32 -0500 EST.
+}
+
 func ExampleError() {
 	err := errors.New("oops: invalid syntax")
 	assert.Error(t, err, "oops")
@@ -88,6 +405,57 @@ func ExampleError() {
 	// Output: Expected error to contain "invalid sintacks", but got "oops: invalid syntax".
 }
 
+func ExampleErrorCode() {
+	err := fmt.Errorf("fetch robot: %w", notFoundError{ID: "bender"})
+
+	assert.ErrorCode(t, err, "NOT_FOUND")
+	assert.ErrorCode(t, err, "PERMISSION_DENIED")
+	assert.ErrorCode(t, errors.New("boom"), "NOT_FOUND")
+
+	// Output: Expected error to have code "PERMISSION_DENIED", but got "NOT_FOUND".
+	// Expected error to have code "NOT_FOUND", but it had no code: "boom".
+}
+
+func ExampleErrorCount() {
+	err := errors.Join(
+		errors.New("name is required"),
+		fmt.Errorf("address: %w", errors.Join(
+			errors.New("street is required"),
+			errors.New("zip is invalid"),
+		)),
+	)
+
+	assert.ErrorCount(t, err, 2)
+	assert.ErrorCount(t, nil, 0)
+	assert.ErrorCount(t, errors.Join(errors.New("a"), errors.New("b")), 3)
+
+	// Output: Expected error to have 3 members, but got 2: ["a" "b"].
+}
+
+func ExampleErrorsContain() {
+	err := errors.Join(
+		errors.New("name is required"),
+		errors.New("email is invalid"),
+	)
+
+	assert.ErrorsContain(t, err, "name", "email")
+	assert.ErrorsContain(t, err, "required\nemail")
+
+	// Output: Expected error to have a member containing "required\nemail", but got ["name is required" "email is invalid"].
+}
+
+func ExampleExitsWith() {
+	assert.ExitsWith(t, 0, exec.Command("sh", "-c", "exit 0"))
+	assert.ExitsWith(t, 0, exec.Command("sh", "-c", "echo starting; echo invalid flag >&2; exit 2"))
+
+	// Output: Expected exit code to be 0, but got 2.
+	// stdout:
+	// starting
+	//
+	// stderr:
+	// invalid flag
+}
+
 func ExampleFalse() {
 	assert.False(t, "true", true)
 	assert.False(t, "false", false)
@@ -95,6 +463,454 @@ func ExampleFalse() {
 	// Output: Expected true to be false, but got true.
 }
 
+func ExampleFieldsMatch() {
+	type User struct {
+		ID    int
+		Name  string
+		Email string
+	}
+
+	want := User{Name: "Bender", Email: "bender@planetexpress.com"}
+	got := User{ID: 42, Name: "Bender", Email: "flexo@planetexpress.com"}
+
+	assert.FieldsMatch(t, "user", want, got, "Name")
+	assert.FieldsMatch(t, "user", want, &got, "Name")
+	assert.FieldsMatch(t, "user", want, "Bender", "Name")
+	assert.FieldsMatch(t, "user", &want, &got, "Name", "Email")
+	assert.FieldsMatch(t, "user", want, got, "Age")
+
+	// Output: Expected user to be structs of the same type, but got assert_test.User and string.
+	// Expected user.Email to be bender@planetexpress.com, but got flexo@planetexpress.com.
+	// Expected user to have field Age, but it didn't.
+}
+
+func ExampleFileContains() {
+	fsys := fstest.MapFS{
+		"config.yaml": {Data: []byte("port: 8080\n")},
+	}
+
+	assert.FileContains(t, fsys, "config.yaml", "port: 8080")
+	assert.FileContains(t, fsys, "config.yaml", "port: 9090")
+
+	// Output: Expected config.yaml to contain "port: 9090", but got "port: 8080\n".
+}
+
+func ExampleFileContent() {
+	fsys := fstest.MapFS{
+		"VERSION": {Data: []byte("1.2.3\n")},
+	}
+
+	assert.FileContent(t, fsys, "VERSION", "1.2.3\n")
+	assert.FileContent(t, fsys, "VERSION", "1.2.3")
+
+	// Output: Expected VERSION to contain exactly "1.2.3", but got "1.2.3\n".
+}
+
+func ExampleFileExists() {
+	fsys := fstest.MapFS{
+		"testdata/user.json": {Data: []byte(`{}`)},
+	}
+
+	assert.FileExists(t, fsys, "testdata")
+	assert.FileExists(t, fsys, "testdata/user.json")
+	assert.FileExists(t, fsys, "testdata/robot.json")
+
+	// Output: Expected testdata/robot.json to exist, but got open testdata/robot.json: file does not exist.
+}
+
+func ExampleFileMode() {
+	fsys := fstest.MapFS{
+		"id_ed25519": {Data: []byte("secret"), Mode: 0o600},
+	}
+
+	assert.FileMode(t, fsys, "id_ed25519", 0o600)
+	assert.FileMode(t, fsys, "id_ed25519", 0o644)
+
+	// Output: Expected id_ed25519 to have mode -rw-r--r--, but got -rw-------.
+}
+
+func ExampleGenerator() {
+	type Point struct {
+		X, Y int
+	}
+
+	// Only generate points in the first quadrant.
+	quadrant := assert.Generator(func(r *rand.Rand, size int) Point {
+		return Point{X: r.IntN(size + 1), Y: r.IntN(size + 1)}
+	})
+
+	assert.Property(t, "first quadrant", func(p Point) bool {
+		return p.X >= 0 && p.Y >= 0
+	}, quadrant, assert.Iterations(1000))
+
+	// Output:
+}
+
+func ExampleGraphQLData() {
+	resp := httptest.NewRecorder()
+	resp.Header().Set("Content-Type", "application/json")
+	io.WriteString(resp, `{"data": {"robot": {"name": "Bender"}}}`)
+
+	var data struct {
+		Robot struct {
+			Name string `json:"name"`
+		} `json:"robot"`
+	}
+	assert.GraphQLData(t, resp, &data)
+	assert.Equal(t, "name", "Bender", data.Robot.Name)
+
+	resp = httptest.NewRecorder()
+	io.WriteString(resp, `{"data": null, "errors": [{"message": "not found"}]}`)
+	assert.GraphQLData(t, resp, &data)
+
+	// Output: Expected GraphQL response to contain data, but it didn't.
+}
+
+func ExampleHeader() {
+	resp := new(http.Response)
+
+	header := http.Header{}
+	header.Set("X-Request-Id", "abc123")
+	resp.Header = header
+
+	assert.Header(t, resp, "X-Request-Id", "abc123")
+	assert.Header(t, resp, "X-Request-Id", "def456")
+
+	// Output: Expected header X-Request-Id to be def456, but got abc123.
+}
+
+func ExampleHeaderAbsent() {
+	resp := new(http.Response)
+
+	header := http.Header{}
+	header.Set("X-Powered-By", "PHP/5.4.0")
+	resp.Header = header
+
+	assert.HeaderAbsent(t, resp, "Server")
+	assert.HeaderAbsent(t, resp, "X-Powered-By")
+
+	// Output: Expected header X-Powered-By to be absent, but got "PHP/5.4.0".
+}
+
+func ExampleHeaderPresent() {
+	resp := new(http.Response)
+
+	header := http.Header{}
+	header.Set("X-Request-Id", "abc123")
+	resp.Header = header
+
+	assert.HeaderPresent(t, resp, "X-Request-Id")
+	assert.HeaderPresent(t, resp, "x-request-id")
+	assert.HeaderPresent(t, resp, "X-Trace-Id")
+
+	// Output: Expected header X-Trace-Id to be present, but it wasn't.
+}
+
+func ExampleImplements() {
+	assert.Implements[io.Reader](t, "reader", strings.NewReader("Hello"))
+	assert.Implements[io.ReadWriteCloser](t, "reader", strings.NewReader("Hello"))
+	assert.Implements[io.Reader](t, "nothing", nil)
+
+	// Output: Expected reader to implement io.ReadWriteCloser, but *strings.Reader is missing methods: Close, Write.
+	// Expected nothing to implement io.Reader, but it was nil.
+}
+
+func ExampleIsType() {
+	var err error = &json.SyntaxError{Offset: 12}
+
+	syntaxErr, result := assert.IsType[*json.SyntaxError](t, "error", err)
+	if result.OK() {
+		assert.Equal(t, "offset", 12, syntaxErr.Offset)
+	}
+
+	assert.IsType[*json.UnmarshalTypeError](t, "error", err)
+
+	// Output: Expected error to be of type *json.UnmarshalTypeError, but got *json.SyntaxError.
+}
+
+func ExampleJSONEqual() {
+	want := `{"name": "Bender", "tags": ["robot", "bender"], "age": 4}`
+
+	assert.JSONEqual(t, "robot", want, `{"age": 4.0, "tags": ["robot", "bender"], "name": "Bender"}`)
+	assert.JSONEqual(t, "robot", want, `{"name": "Bender", "tags": ["robot", "thief"], "age": 4}`)
+	assert.JSONEqual(t, "robot", want, `{"name": "Bender", "tags": ["robot", "bender"]}`)
+	assert.JSONEqual(t, "robot", want, `{"name": "Bender", "tags": ["robot", "bender"], "age": 4, "id": 1}`)
+
+	// Output: Expected robot at $.tags[1] to be "bender", but got "thief".
+	// Expected robot to have $.age, but it didn't.
+	// Expected robot to not have $.id, but got 1.
+}
+
+func ExampleJSONIgnore() {
+	want := `{"name": "Bender", "meta": {"version": 2}}`
+	got := `{"name": "Bender", "meta": {"version": 2, "generatedAt": "3000-01-01T00:00:00Z"}}`
+
+	assert.JSONEqual(t, "response", want, got, assert.JSONIgnore("$.meta.generatedAt"))
+	assert.JSONEqual(t, "response", want, got)
+
+	// Output: Expected response to not have $.meta.generatedAt, but got "3000-01-01T00:00:00Z".
+}
+
+func ExampleJSONWildcard() {
+	want := `{"items": [{"id": "?", "sku": "BENDER-22"}, {"id": "?", "sku": "FLEXO-22"}]}`
+
+	assert.JSONEqual(t, "order", want,
+		`{"items": [{"id": "a1", "sku": "BENDER-22"}, {"id": "b2", "sku": "FLEXO-22"}]}`,
+		assert.JSONWildcard("$.items[*].id"),
+	)
+	assert.JSONEqual(t, "order", want,
+		`{"items": [{"id": "a1", "sku": "BENDER-22"}, {"sku": "FLEXO-22"}]}`,
+		assert.JSONWildcard("$.items[*].id"),
+	)
+
+	// Output: Expected order to have $.items[1].id, but it didn't.
+}
+
+func ExampleLoggedError() {
+	ctx := context.Background()
+	logs := assert.NewLogs()
+	logger := log.New(log.WithOutput(logs))
+
+	logger.Error(ctx, "failed to connect to database", "attempt", 3)
+
+	assert.LoggedError(t, logs, "database")
+	assert.LoggedError(t, logs, "cache")
+
+	// Output: Expected an error to be logged containing "cache", but got ["failed to connect to database"].
+}
+
+func ExampleLoggedWithAttr() {
+	ctx := context.Background()
+	logs := assert.NewLogs()
+	logger := log.New(log.WithOutput(logs))
+
+	logger.Info(ctx, "request handled", "status", 200, "path", "/robots")
+
+	assert.LoggedWithAttr(t, logs, "status", 200)
+	assert.LoggedWithAttr(t, logs, "path", "/humans")
+	assert.LoggedWithAttr(t, logs, "user_id", 42)
+
+	// Output: Expected a record to be logged with path=/humans, but got [/robots].
+	// Expected a record to be logged with user_id, but none were.
+}
+
+func ExampleMapContains() {
+	ages := map[string]int{"Fry": 25, "Leela": 25, "Bender": 4}
+
+	assert.MapContains(t, "ages", ages, "Fry", 25)
+	assert.MapContains(t, "ages", ages, "Bender", 1059)
+	assert.MapContains(t, "ages", ages, "Zoidberg", 87)
+
+	// Output: Expected ages[Bender] to be 1059, but got 4.
+	// Expected ages to contain key Zoidberg, but it had keys [Bender Fry Leela].
+}
+
+func ExampleMapContainsKey() {
+	headers := map[string][]string{"Accept": {"*/*"}}
+
+	assert.MapContainsKey(t, "headers", headers, "Accept")
+	assert.MapContainsKey(t, "headers", headers, "Authorization")
+
+	// Output: Expected headers to contain key Authorization, but it had keys [Accept].
+}
+
+func ExampleMapContainsValue() {
+	ages := map[string]int{"Fry": 25, "Leela": 25}
+
+	assert.MapContainsValue(t, "ages", ages, 25)
+	assert.MapContainsValue(t, "ages", ages, 1000)
+
+	// Output: Expected ages to contain value 1000, but it didn't.
+}
+
+func ExampleMatchesJSONSchema() {
+	schema := []byte(`{
+		"type": "object",
+		"required": ["name", "age"],
+		"properties": {
+			"name": {"type": "string"},
+			"age": {"type": "integer", "minimum": 0}
+		}
+	}`)
+
+	assert.MatchesJSONSchema(t, schema, []byte(`{"name": "Bender", "age": 4}`))
+	assert.MatchesJSONSchema(t, schema, []byte(`{"name": 22, "age": -1}`))
+
+	// Output: Expected document to match JSON schema, but it didn't:
+	// 	/age: must be >= 0 but found -1
+	// 	/name: expected string, but got number
+}
+
+func ExampleMaxAllocs() {
+	var sink []byte
+
+	assert.MaxAllocs(t, "sum", 0, func() {
+		total := 0
+		for i := range 10 {
+			total += i
+		}
+		_ = total
+	})
+	assert.MaxAllocs(t, "buffer", 0, func() {
+		sink = make([]byte, 64)
+	})
+
+	_ = sink
+
+	// Output: Expected buffer to allocate at most 0 times per run, but got 1.
+}
+
+func ExampleMultipartField() {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	w.WriteField("title", "Bender's Big Score")
+	w.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/movies", &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	assert.MultipartField(t, req, "title", "Bender's Big Score")
+	assert.MultipartField(t, req, "title", "The Beast with a Billion Backs")
+	assert.MultipartField(t, req, "year", "2007")
+
+	// Output: Expected form field title to be "The Beast with a Billion Backs", but got "Bender's Big Score".
+	// Expected form to have field year, but it didn't.
+}
+
+func ExampleMultipartFile() {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", `form-data; name="avatar"; filename="bender.txt"`)
+	h.Set("Content-Type", "text/plain")
+	part, _ := w.CreatePart(h)
+	part.Write([]byte("Bite my shiny metal avatar"))
+	w.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/avatars", &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	assert.MultipartFile(t, req, "avatar", assert.FilePart{
+		Filename:    "bender.txt",
+		ContentType: "text/plain",
+		Content:     "Bite my shiny metal avatar",
+	})
+	assert.MultipartFile(t, req, "avatar", assert.FilePart{
+		Filename:    "flexo.txt",
+		ContentType: "text/plain",
+		Content:     "Bite my shiny metal avatar",
+	})
+
+	// Output: Expected form file avatar to have filename "flexo.txt", but got "bender.txt".
+}
+
+func ExampleNegative() {
+	assert.Negative(t, "offset", -5)
+	assert.Negative(t, "offset", 0)
+
+	// Output: Expected offset to be negative, but got 0.
+}
+
+func ExampleNew() {
+	a := assert.New(t)
+
+	a.Equal("int", 42, 42)
+	a.Equal("int", 42, 13)
+	a.Equal("int", 42, int64(42))
+	a.SliceEqual("slice", []string{"a", "b"}, []string{"b", "a"})
+
+	// Output: Expected int to be 42, but got 13.
+	// Expected int to be 42 (int), but got 42 (int64).
+	// Expected slice to be [a b], but got [b a].
+}
+
+func ExampleNewCollector() {
+	c := assert.NewCollector(t)
+
+	assert.Equal(c, "name", "Bender", "Bender")
+	assert.Equal(c, "model", "Bending Unit 22", "Bending Unit 1729")
+	assert.True(c, "functional", false)
+
+	fmt.Println("Nothing has been reported yet.")
+	c.Report()
+
+	// Output: Nothing has been reported yet.
+	// Expected 3 assertions to pass, but 2 failed:
+	// 	model: Expected model to be Bending Unit 22, but got Bending Unit 1729.
+	// 	functional: Expected functional to be true, but got false.
+}
+
+func ExampleNoErrorsLogged() {
+	ctx := context.Background()
+	logs := assert.NewLogs()
+	logger := log.New(log.WithOutput(logs))
+
+	logger.Info(ctx, "starting")
+	assert.NoErrorsLogged(t, logs)
+
+	logger.Error(ctx, "crashed")
+	assert.NoErrorsLogged(t, logs)
+
+	// Output: Expected no errors to be logged, but got ["crashed"].
+}
+
+func ExampleNoErrorsLogged_fatal() {
+	ctx := context.Background()
+	logs := assert.NewLogs()
+	logger := log.New(log.WithOutput(logs), log.WithExitFunc(func(int) {}))
+
+	logger.Warn(ctx, "disk almost full")
+	assert.NoErrorsLogged(t, logs)
+
+	logger.Fatal(ctx, "disk full")
+	assert.NoErrorsLogged(t, logs)
+	assert.LoggedError(t, logs, "disk full")
+
+	// Output: Expected no errors to be logged, but got ["disk full"].
+}
+
+func ExampleNoGoroutineLeak() {
+	check := assert.NoGoroutineLeak(t)
+
+	done := make(chan struct{})
+	go func() {
+		<-done
+	}()
+	close(done)
+
+	// In a real test, check would usually be passed to t.Cleanup or deferred.
+	check()
+
+	// Output:
+}
+
+func ExampleNoGraphQLErrors() {
+	resp := httptest.NewRecorder()
+	io.WriteString(resp, `{"data": {"robot": {"name": "Bender"}}}`)
+	assert.NoGraphQLErrors(t, resp)
+
+	resp = httptest.NewRecorder()
+	io.WriteString(resp, `{
+		"data": {"robot": null},
+		"errors": [
+			{"message": "robot not found", "path": ["robot"]},
+			{"message": "rate limit exceeded"}
+		]
+	}`)
+	assert.NoGraphQLErrors(t, resp)
+
+	// Output: Expected no GraphQL errors, but got 2:
+	// 	robot not found (path robot)
+	// 	rate limit exceeded
+}
+
+func ExampleNonNegative() {
+	assert.NonNegative(t, "balance", 0)
+	assert.NonNegative(t, "balance", -12.5)
+
+	// Output: Expected balance to be non-negative, but got -12.5.
+}
+
 func ExampleNotBlank() {
 	assert.NotBlank(t, "the blank string", "")
 	assert.NotBlank(t, "only spaces", "    ")
@@ -106,6 +922,36 @@ func ExampleNotBlank() {
 	// Expected only spaces to not be blank, but it was.
 }
 
+func ExampleNotDeepEqual() {
+	type Composer struct {
+		Name string
+	}
+
+	bach := Composer{Name: "J.S. Bach"}
+
+	assert.NotDeepEqual(t, "composers", &bach, &Composer{Name: "D. Shostakovich"})
+	assert.NotDeepEqual(t, "composers", &bach, &Composer{Name: "J.S. Bach"})
+
+	// Output: Expected composers to not be equal, but they were.
+}
+
+func ExampleNotEqual() {
+	assert.NotEqual(t, "token", "old-token", "new-token")
+	assert.NotEqual(t, "token", "old-token", "old-token")
+
+	// Output: Expected token to not be old-token, but it was.
+}
+
+func ExampleNumericEqual() {
+	assert.NumericEqual(t, "price", "1.5", "1.50")
+	assert.NumericEqual(t, "quantity", "1000", "1e3")
+	assert.NumericEqual(t, "price", "1.5", "1.05")
+	assert.NumericEqual(t, "price", "1.5", "$1.50")
+
+	// Output: Expected price to be 1.5, but got 1.05.
+	// Expected price to be a number, but got "$1.50".
+}
+
 func ExampleOK() {
 	assert.OK(t, nil)
 	assert.OK(t, errors.New("oops"))
@@ -113,6 +959,361 @@ func ExampleOK() {
 	// Output: Unexpected error: oops.
 }
 
+func ExampleOnFailure() {
+	remove := assert.OnFailure(func(t assert.T, label string) {
+		t.Log("Dumping state for", label)
+	})
+	defer remove()
+
+	assert.Equal(t, "answer", 42, 42)
+	assert.Equal(t, "answer", 42, 13)
+
+	// Output: Expected answer to be 42, but got 13.
+	// Dumping state for answer
+}
+
+func ExamplePositive() {
+	assert.Positive(t, "count", 3)
+	assert.Positive(t, "count", uint(0))
+	assert.Positive(t, "ratio", math.NaN())
+
+	// Output: Expected count to be positive, but got 0.
+	// Expected ratio to be positive, but got NaN.
+}
+
+func ExampleProperty() {
+	reverse := func(s []int) []int {
+		r := make([]int, len(s))
+		for i, v := range s {
+			r[len(s)-1-i] = v
+		}
+		return r
+	}
+
+	assert.Property(t, "reversing twice", func(s []int) bool {
+		return slices.Equal(reverse(reverse(s)), s)
+	})
+
+	// The smallest input for which the property fails is reported.
+	assert.Property(t, "elements below 50", func(s []int) bool {
+		for _, v := range s {
+			if v >= 50 {
+				return false
+			}
+		}
+		return true
+	}, assert.Seed(1))
+
+	// Output: Expected elements below 50 to hold for all inputs, but it failed after 51 tests (seed 1, shrunk 7 times) for []int{50}.
+}
+
+func ExampleReceives() {
+	results := make(chan int, 2)
+	results <- 42
+	results <- 13
+
+	assert.Receives(t, "result", results, 42, 10*time.Millisecond)
+	assert.Receives(t, "result", results, 42, 10*time.Millisecond)
+	assert.Receives(t, "result", results, 42, 10*time.Millisecond)
+
+	// Output: Expected result to be 42, but got 13.
+	// Expected result to receive a value within 10ms, but it didn't.
+}
+
+func ExampleReceivesJSON() {
+	type Event struct {
+		Type string `json:"type"`
+		User string `json:"user"`
+	}
+
+	messages := make(chan []byte, 2)
+	messages <- []byte(`{"type": "joined", "user": "Bender"}`)
+	messages <- []byte(`{"type": "left", "user": "Bender"}`)
+
+	conn := assert.WebSocketFunc(func(ctx context.Context) ([]byte, error) {
+		select {
+		case msg := <-messages:
+			return msg, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	})
+
+	assert.ReceivesJSON(t, conn, &Event{Type: "joined", User: "Bender"}, time.Second)
+	assert.ReceivesJSON(t, conn, &Event{Type: "joined", User: "Flexo"}, time.Second)
+
+	// Output: Expected websocket message to be {joined Flexo}, but got {left Bender}.
+}
+
+func ExampleReceivesMessage() {
+	messages := make(chan []byte, 1)
+	messages <- []byte("hello")
+
+	conn := assert.WebSocketFunc(func(ctx context.Context) ([]byte, error) {
+		select {
+		case msg := <-messages:
+			return msg, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	})
+
+	assert.ReceivesMessage(t, conn, "hello", time.Second)
+	assert.ReceivesMessage(t, conn, "goodbye", 10*time.Millisecond)
+
+	// Output: Expected websocket to receive a message within 10ms, but it didn't.
+}
+
+func ExampleReceivesWithin() {
+	events := make(chan string, 1)
+	go func() {
+		events <- "started"
+		close(events)
+	}()
+
+	event, result := assert.ReceivesWithin(t, "event", events, time.Second)
+	if result.OK() {
+		assert.Equal(t, "event", "started", event)
+	}
+
+	assert.ReceivesWithin(t, "event", events, time.Second)
+
+	// Output: Expected event to receive a value, but it was closed.
+}
+
+func ExampleRedirectsTo() {
+	resp := new(http.Response)
+	resp.StatusCode = http.StatusFound
+
+	header := http.Header{}
+	header.Set("Location", "/login")
+	resp.Header = header
+
+	assert.RedirectsTo(t, resp, "/login")
+	assert.RedirectsTo(t, resp, "/logout")
+
+	resp.StatusCode = http.StatusOK
+	assert.RedirectsTo(t, resp, "/login")
+
+	// Output: Expected redirect to /logout, but got /login.
+	// Expected status code to be a redirect, but got 200.
+}
+
+func ExampleRedirectsToPrefix() {
+	resp := new(http.Response)
+	resp.StatusCode = http.StatusSeeOther
+
+	header := http.Header{}
+	header.Set("Location", "https://example.com/oauth?state=8f14e45f")
+	resp.Header = header
+
+	assert.RedirectsToPrefix(t, resp, "https://example.com/oauth?")
+	assert.RedirectsToPrefix(t, resp, "https://example.org/")
+
+	// Output: Expected redirect to https://example.org/, but got https://example.com/oauth?state=8f14e45f.
+}
+
+func ExampleResult_And() {
+	status, body := 200, "OK"
+
+	ok := assert.Equal(t, "status", 200, status).And(assert.Equal(t, "body", "OK", body)).OK()
+	fmt.Println(ok)
+
+	// Output: true
+}
+
+func ExampleResult_Msgf() {
+	cases := []struct {
+		Name string
+		A, B int
+		Want int
+	}{
+		{Name: "positive", A: 1, B: 2, Want: 3},
+		{Name: "negative", A: -1, B: -2, Want: 3},
+	}
+
+	for i, tc := range cases {
+		assert.Equal(t, "sum", tc.Want, tc.A+tc.B).Msgf("case %d: %s", i, tc.Name)
+	}
+
+	// Output: Expected sum to be 3, but got -3.
+	// case 1: negative
+}
+
+func ExampleRowCount() {
+	db := robotsDB()
+	defer db.Close()
+
+	rows, _ := db.Query("SELECT * FROM robots")
+	assert.RowCount(t, "robots", rows, 2)
+
+	rows, _ = db.Query("SELECT * FROM robots")
+	assert.RowCount(t, "robots", rows, 3)
+
+	// Output: Expected robots to have 3 rows, but got 2.
+}
+
+func ExampleRowsEqual() {
+	db := robotsDB()
+	defer db.Close()
+
+	rows, _ := db.Query("SELECT id, name FROM robots ORDER BY id")
+	assert.RowsEqual(t, "robots", [][]any{{1, "Bender"}, {2, "Flexo"}}, rows)
+
+	rows, _ = db.Query("SELECT id, name FROM robots ORDER BY id")
+	assert.RowsEqual(t, "robots", [][]any{{1, "Bender"}}, rows)
+
+	// Output: Expected robots to be [[1 Bender]], but got [[1 Bender] [2 Flexo]].
+}
+
+func ExampleSafe() {
+	s := assert.Safe(t)
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.True(s, fmt.Sprintf("job %d", i), i != 2)
+		}()
+	}
+	wg.Wait()
+
+	// In a real test, s.Done would usually be called automatically when the
+	// test finishes.
+	s.Done()
+
+	assert.ShouldPanic(t, func() {
+		assert.True(s, "late job", false)
+	})
+
+	// Output: Expected job 2 to be true, but got false.
+}
+
+func ExampleScanOne() {
+	db := robotsDB()
+	defer db.Close()
+
+	var name string
+	rows, _ := db.Query("SELECT name FROM robots WHERE id = 1")
+	if assert.ScanOne(t, rows, &name).OK() {
+		assert.Equal(t, "name", "Bender", name)
+	}
+
+	rows, _ = db.Query("SELECT name FROM robots")
+	assert.ScanOne(t, rows, &name)
+
+	rows, _ = db.Query("SELECT name FROM robots WHERE id = 3")
+	assert.ScanOne(t, rows, &name)
+
+	// Output: Expected exactly one row, but got more.
+	// Expected exactly one row, but got none.
+}
+
+func ExampleSetCompact() {
+	type Part struct {
+		Name     string
+		Quantity int
+	}
+	type Robot struct {
+		Name  string
+		Parts []Part
+	}
+
+	want := Robot{Name: "Bender", Parts: []Part{{"antenna", 1}, {"beer cooler", 1}, {"cigar holder", 1}, {"shiny metal plating", 42}}}
+	got := Robot{Name: "Bender", Parts: []Part{{"antenna", 1}, {"beer cooler", 1}, {"cigar holder", 1}, {"shiny metal plating", 41}}}
+
+	// Values that are too long to read on one line are pretty-printed.
+	assert.DeepEqual(t, "robot", want, got)
+
+	assert.SetCompact(true)
+	defer assert.SetCompact(false)
+
+	assert.DeepEqual(t, "robot", want, got)
+
+	// Output: Expected robot to be assert_test.Robot{
+	// 	Name: "Bender",
+	// 	Parts: []assert_test.Part{
+	// 		assert_test.Part{
+	// 			Name: "antenna",
+	// 			Quantity: 1,
+	// 		},
+	// 		assert_test.Part{
+	// 			Name: "beer cooler",
+	// 			Quantity: 1,
+	// 		},
+	// 		assert_test.Part{
+	// 			Name: "cigar holder",
+	// 			Quantity: 1,
+	// 		},
+	// 		assert_test.Part{
+	// 			Name: "shiny metal plating",
+	// 			Quantity: 42,
+	// 		},
+	// 	},
+	// }, but got assert_test.Robot{
+	// 	Name: "Bender",
+	// 	Parts: []assert_test.Part{
+	// 		assert_test.Part{
+	// 			Name: "antenna",
+	// 			Quantity: 1,
+	// 		},
+	// 		assert_test.Part{
+	// 			Name: "beer cooler",
+	// 			Quantity: 1,
+	// 		},
+	// 		assert_test.Part{
+	// 			Name: "cigar holder",
+	// 			Quantity: 1,
+	// 		},
+	// 		assert_test.Part{
+	// 			Name: "shiny metal plating",
+	// 			Quantity: 41,
+	// 		},
+	// 	},
+	// }.
+	// Expected robot to be {Bender [{antenna 1} {beer cooler 1} {cigar holder 1} {shiny metal plating 42}]}, but got {Bender [{antenna 1} {beer cooler 1} {cigar holder 1} {shiny metal plating 41}]}.
+}
+
+func ExampleSetVerbose() {
+	type Robot struct {
+		Name  string
+		Parts []string
+	}
+
+	assert.SetVerbose(true)
+	defer assert.SetVerbose(false)
+
+	assert.Equal(t, "robot", "Bender", "Flexo")
+	assert.DeepEqual(t, "robot", Robot{Name: "Bender"}, Robot{Name: "Flexo"})
+	assert.EqualFunc(t, "robot",
+		Robot{Name: "Bender", Parts: []string{"antenna"}},
+		Robot{Name: "Flexo", Parts: []string{"beard", "antenna"}},
+		func(want, got Robot) bool { return want.Name == got.Name },
+	)
+
+	// Output: Expected robot to be Bender, but got Flexo.
+	// Expected robot to be assert_test.Robot{
+	// 	Name: "Bender",
+	// 	Parts: []string(nil),
+	// }, but got assert_test.Robot{
+	// 	Name: "Flexo",
+	// 	Parts: []string(nil),
+	// }.
+	// Expected robot to be assert_test.Robot{
+	// 	Name: "Bender",
+	// 	Parts: []string{
+	// 		"antenna",
+	// 	},
+	// }, but got assert_test.Robot{
+	// 	Name: "Flexo",
+	// 	Parts: []string{
+	// 		"beard",
+	// 		"antenna",
+	// 	},
+	// }.
+}
+
 func ExampleShouldPanic() {
 	assert.ShouldPanic(t, func() {})
 	assert.ShouldPanic(t, func() {
@@ -122,6 +1323,34 @@ func ExampleShouldPanic() {
 	// Output: Expected function to panic, but it didn't.
 }
 
+func ExampleSkipWithoutEnv() {
+	os.Setenv("ROBOT_NAME", "Bender")
+	defer os.Unsetenv("ROBOT_NAME")
+
+	name := assert.SkipWithoutEnv(t, "ROBOT_NAME")[0]
+	fmt.Println(name)
+
+	assert.SkipWithoutEnv(t, "ROBOT_NAME", "ROBOT_SERIAL_NUMBER")
+
+	// Output: Bender
+	// Skipping because environment variable ROBOT_SERIAL_NUMBER is not set.
+}
+
+func ExampleSkipWithoutService() {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+	addr := ln.Addr().String()
+
+	assert.SkipWithoutService(t, "tcp", addr)
+
+	ln.Close()
+	assert.SkipWithoutService(t, "unix", "/nonexistent/robot.sock")
+
+	// Output: Skipping because /nonexistent/robot.sock is not reachable: dial unix /nonexistent/robot.sock: connect: no such file or directory.
+}
+
 func ExampleSliceEqual() {
 	control := []int{1, 2, 3}
 	reversed := []int{3, 2, 1}
@@ -148,6 +1377,17 @@ func ExampleSliceEqual() {
 	// Expected struct elements to be [{1} {2} {3}], but got [{3} {1} {2}].
 }
 
+func ExampleSliceInDelta() {
+	want := []float64{0.25, 0.5, 0.75}
+
+	assert.SliceInDelta(t, "weights", want, []float64{0.2500001, 0.4999999, 0.75}, 1e-6)
+	assert.SliceInDelta(t, "weights", want, []float64{0.25, 0.5, 0.8}, 1e-6)
+	assert.SliceInDelta(t, "weights", want, []float64{0.25, 0.5}, 1e-6)
+
+	// Output: Expected weights[2] to be within 1e-06 of 0.75, but got 0.8 (delta 0.050000000000000044).
+	// Expected weights to have 3 elements, but got 2.
+}
+
 func ExampleStatusCode() {
 	resp := new(http.Response)
 	resp.StatusCode = 200
@@ -158,9 +1398,137 @@ func ExampleStatusCode() {
 	// Output: Expected status code to be 418, but got 200.
 }
 
+func ExampleStatusCode_recorder() {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// HTTP assertions accept a recorder directly, so there's no need to call
+	// rec.Result() first.
+	assert.StatusCode(t, http.StatusTeapot, rec)
+	assert.StatusCode(t, http.StatusOK, rec)
+
+	// Output: Expected status code to be 200, but got 418.
+}
+
+func ExampleThat() {
+	roles := []string{"viewer", "editor"}
+
+	assert.That(t, roles).Named("roles").NotNil().Contains("editor")
+	assert.That(t, roles).Named("roles").Contains("admin").Equals([]string{"admin"})
+
+	var missing []string
+	assert.That(t, missing).Named("roles").NotNil().Contains("admin")
+
+	assert.That(t, "hello, world").Contains("world").Equals("hello, world")
+
+	// Output: Expected roles to contain admin, but got [viewer editor].
+	// Expected roles to not be nil, but it was.
+}
+
+func ExampleTimeEqual() {
+	created := time.Date(2024, 2, 1, 17, 1, 32, 123456789, time.UTC)
+
+	// Databases often store times with reduced precision and in a different
+	// location from the one they were created in.
+	stored := created.Truncate(time.Microsecond).In(time.FixedZone("EST", -5*60*60))
+
+	assert.TimeEqual(t, "created at", created, stored, 0)
+	assert.TimeEqual(t, "created at", created, stored, time.Microsecond)
+
+	// Output: Expected created at to be 2024-02-01T17:01:32.123456789Z, but got 2024-02-01T17:01:32.123456Z.
+}
+
+func ExampleTrackStats() {
+	stats := assert.TrackStats(t)
+
+	assert.Equal(t, "answer", 42, 42)
+	assert.True(t, "truth", false)
+	assert.OK(t, nil)
+
+	fmt.Println(stats)
+
+	// Output: Expected truth to be true, but got false.
+	// Made 3 assertions, 1 failed.
+}
+
 func ExampleTrue() {
 	assert.True(t, "true", true)
 	assert.True(t, "false", false)
 
 	// Output: Expected false to be true, but got false.
 }
+
+func ExampleValidEmail() {
+	assert.ValidEmail(t, "email", "bender@planetexpress.com")
+	assert.ValidEmail(t, "email", "bender.planetexpress.com")
+	assert.ValidEmail(t, "email", "bender@")
+	assert.ValidEmail(t, "email", "Bender <bender@planetexpress.com>")
+
+	// Output: Expected email to contain an @, but got "bender.planetexpress.com".
+	// Expected email to have a domain after the @, but got "bender@".
+	// Expected email to be a bare email address, but got "Bender <bender@planetexpress.com>".
+}
+
+func ExampleValidURL() {
+	assert.ValidURL(t, "callback", "https://example.com/hooks")
+	assert.ValidURL(t, "callback", "example.com/hooks")
+	assert.ValidURL(t, "callback", "file:///etc/hosts")
+	assert.ValidURL(t, "callback", "https://exa mple.com")
+
+	// Output: Expected callback to have a scheme, but got "example.com/hooks".
+	// Expected callback to have a host, but got "file:///etc/hosts".
+	// Expected callback to be a valid URL, but got parse "https://exa mple.com": invalid character " " in host name.
+}
+
+func ExampleValidUUID() {
+	assert.ValidUUID(t, "user ID", "f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	assert.ValidUUID(t, "user ID", "f47ac10b-58cc-4372-a567-0e02b2c3d479", 4)
+	assert.ValidUUID(t, "user ID", "f47ac10b58cc4372a5670e02b2c3d479")
+	assert.ValidUUID(t, "user ID", "f47ac10b-58cc-1372-a567-0e02b2c3d479", 4)
+	assert.ValidUUID(t, "user ID", "f47ac10b-58cc-4372-c567-0e02b2c3d479", 4)
+
+	// Output: Expected user ID to be a valid UUID, but got "f47ac10b58cc4372a5670e02b2c3d479".
+	// Expected user ID to be a version 4 UUID, but got version 1.
+	// Expected user ID to be an RFC 9562 UUID, but got "f47ac10b-58cc-4372-c567-0e02b2c3d479" with variant bits 1100.
+}
+
+func ExampleXMLEqual() {
+	want := `<feed><entry id="1" lang="en"><title>Bender</title><summary/></entry></feed>`
+	got := `
+<feed>
+	<entry lang="en" id="1">
+		<title>Bender</title>
+		<summary></summary>
+	</entry>
+</feed>`
+
+	assert.XMLEqual(t, "feed", want, got)
+	assert.XMLEqual(t, "feed", want, strings.Replace(got, "Bender", "Flexo", 1))
+	assert.XMLEqual(t, "feed", want, strings.Replace(got, `lang="en"`, `lang="fr"`, 1))
+
+	// Output: Expected feed to be equivalent XML, but /feed[1]/entry[1]/title[1] has text "Flexo" instead of "Bender".
+	// Expected feed to be equivalent XML, but /feed[1]/entry[1] has attributes [id="1" lang="fr"] instead of [id="1" lang="en"].
+}
+
+func ExampleYAMLEqual() {
+	want := `
+name: bender
+ports: [80, 443]
+`
+	got := `
+# Generated by robotctl
+ports:
+  - 80
+  - 443
+name: "bender"
+`
+
+	assert.YAMLEqual(t, "config", want, got)
+	assert.YAMLEqual(t, "config", want, "name: flexo\nports: [80, 443]")
+
+	// Output: Expected config to be map[name:bender ports:[80 443]], but got map[name:flexo ports:[80 443]].
+}
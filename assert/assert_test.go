@@ -3,6 +3,8 @@ package assert_test
 import (
 	"fmt"
 	"os"
+
+	"github.com/haleyrc/lib/sqlite"
 )
 
 // N.B.: These definitions need to exist in a separate file from the testable
@@ -25,3 +27,30 @@ func (mockT) Helper() {}
 func (mockT) Log(args ...any) {
 	fmt.Fprintln(os.Stdout, args...)
 }
+
+func (mockT) Skip(args ...any) {
+	fmt.Fprintln(os.Stdout, args...)
+}
+
+// robotsDB returns an in-memory database containing a small table of robots
+// for use in the SQL examples.
+func robotsDB() *sqlite.DB {
+	db, err := sqlite.Open(":memory:")
+	if err != nil {
+		panic(err)
+	}
+	// Every connection to an in-memory database gets its own database, so we
+	// have to limit the pool to a single connection.
+	db.SetMaxOpenConns(1)
+	db.MustExec(`CREATE TABLE robots (id INTEGER PRIMARY KEY, name TEXT NOT NULL)`)
+	db.MustExec(`INSERT INTO robots (id, name) VALUES (1, 'Bender'), (2, 'Flexo')`)
+	return db
+}
+
+// notFoundError is a coded error for use in the ErrorCode examples.
+type notFoundError struct {
+	ID string
+}
+
+func (e notFoundError) Code() string  { return "NOT_FOUND" }
+func (e notFoundError) Error() string { return e.ID + " not found" }
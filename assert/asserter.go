@@ -0,0 +1,473 @@
+package assert
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"net/http"
+	"os/exec"
+	"reflect"
+	"time"
+)
+
+// Asserter binds the assertions in this package to a single T so that long
+// tests don't need to pass t into every call.
+//
+// To create a new Asserter, call New with the T for the current test. The
+// free functions remain available and an Asserter can be freely mixed with
+// them.
+//
+// Since methods can't have type parameters, the HTTP assertions on Asserter
+// only accept an *http.Response. Call Result on a *httptest.ResponseRecorder
+// or use the free functions, which accept either.
+type Asserter struct {
+	t T
+}
+
+// New returns an Asserter whose methods report failures to t.
+//
+//	func TestSomeThing(t *testing.T) {
+//		a := assert.New(t)
+//		a.Equal("answer", 42, compute())
+//	}
+func New(t T) Asserter {
+	return Asserter{t: t}
+}
+
+// T returns the T that the Asserter reports failures to. A few generic
+// assertions, such as IsType and Receives, need a type parameter that a
+// method can't have, so pass T to them instead, e.g.:
+//
+//	robot, _ := assert.IsType[*Robot](a.T(), "robot", v)
+func (a Asserter) T() T {
+	return a.t
+}
+
+// BodyContains is equivalent to calling [BodyContains] with the bound T.
+func (a Asserter) BodyContains(resp *http.Response, want string) Result {
+	a.t.Helper()
+	return BodyContains(a.t, resp, want)
+}
+
+// BodyGolden is equivalent to calling [BodyGolden] with the bound T.
+func (a Asserter) BodyGolden(resp *http.Response, path string) Result {
+	a.t.Helper()
+	return BodyGolden(a.t, resp, path)
+}
+
+// CompletesWithin is equivalent to calling [CompletesWithin] with the bound T.
+func (a Asserter) CompletesWithin(label string, timeout time.Duration, f func()) Result {
+	a.t.Helper()
+	return CompletesWithin(a.t, label, timeout, f)
+}
+
+// Condition is equivalent to calling [Condition] with the bound T.
+func (a Asserter) Condition(label string, f func() bool) Result {
+	a.t.Helper()
+	return Condition(a.t, label, f)
+}
+
+// Conditionf is equivalent to calling [Conditionf] with the bound T.
+func (a Asserter) Conditionf(f func() bool, format string, args ...any) Result {
+	a.t.Helper()
+	return Conditionf(a.t, f, format, args...)
+}
+
+// ContentType is equivalent to calling [ContentType] with the bound T.
+func (a Asserter) ContentType(resp *http.Response, want string) Result {
+	a.t.Helper()
+	return ContentType(a.t, resp, want)
+}
+
+// ContextAlive is equivalent to calling [ContextAlive] with the bound T.
+func (a Asserter) ContextAlive(ctx context.Context) Result {
+	a.t.Helper()
+	return ContextAlive(a.t, ctx)
+}
+
+// ContextCanceled is equivalent to calling [ContextCanceled] with the bound T.
+func (a Asserter) ContextCanceled(ctx context.Context) Result {
+	a.t.Helper()
+	return ContextCanceled(a.t, ctx)
+}
+
+// ContextDeadlineExceeded is equivalent to calling [ContextDeadlineExceeded]
+// with the bound T.
+func (a Asserter) ContextDeadlineExceeded(ctx context.Context) Result {
+	a.t.Helper()
+	return ContextDeadlineExceeded(a.t, ctx)
+}
+
+// Cookie is equivalent to calling [Cookie] with the bound T.
+func (a Asserter) Cookie(resp *http.Response, name string) (*http.Cookie, Result) {
+	a.t.Helper()
+	return Cookie(a.t, resp, name)
+}
+
+// CSVEqual is equivalent to calling [CSVEqual] with the bound T.
+func (a Asserter) CSVEqual(label, want, got string, opts ...CSVOption) Result {
+	a.t.Helper()
+	return CSVEqual(a.t, label, want, got, opts...)
+}
+
+// DeepEqual is equivalent to calling [DeepEqual] with the bound T.
+func (a Asserter) DeepEqual(label string, want, got any) Result {
+	a.t.Helper()
+	return DeepEqual(a.t, label, want, got)
+}
+
+// DeepEqualIgnoring is equivalent to calling [DeepEqualIgnoring] with the
+// bound T.
+func (a Asserter) DeepEqualIgnoring(label string, want, got any, ignore ...string) Result {
+	a.t.Helper()
+	return DeepEqualIgnoring(a.t, label, want, got, ignore...)
+}
+
+// DeepEqualWith is equivalent to calling [DeepEqualWith] with the bound T.
+func (a Asserter) DeepEqualWith(label string, want, got any, opts ...Comparer) Result {
+	a.t.Helper()
+	return DeepEqualWith(a.t, label, want, got, opts...)
+}
+
+// DirEqual is equivalent to calling [DirEqual] with the bound T.
+func (a Asserter) DirEqual(want, got fs.FS) Result {
+	a.t.Helper()
+	return DirEqual(a.t, want, got)
+}
+
+// DurationWithin is equivalent to calling [DurationWithin] with the bound T.
+func (a Asserter) DurationWithin(label string, want, got, tolerance time.Duration) Result {
+	a.t.Helper()
+	return DurationWithin(a.t, label, want, got, tolerance)
+}
+
+// Equal is equivalent to calling [Equal] with the bound T. Since methods can't
+// have type parameters, want and got are compared as interface values, which
+// panics if their dynamic types are not comparable. Values of different types
+// are never equal, so a.Equal("n", 42, int64(42)) fails; the failure message
+// includes both types in that case since the values may look the same.
+func (a Asserter) Equal(label string, want, got any) Result {
+	a.t.Helper()
+	if reflect.TypeOf(want) != reflect.TypeOf(got) {
+		return fail(a.t, label, "Expected %s to be %v (%T), but got %v (%T).", label, wantValue(want), want, gotValue(got), got)
+	}
+	return Equal(a.t, label, want, got)
+}
+
+// EqualExportedFields is equivalent to calling [EqualExportedFields] with the
+// bound T.
+func (a Asserter) EqualExportedFields(label string, want, got any) Result {
+	a.t.Helper()
+	return EqualExportedFields(a.t, label, want, got)
+}
+
+// EqualFold is equivalent to calling [EqualFold] with the bound T.
+func (a Asserter) EqualFold(label, want, got string) Result {
+	a.t.Helper()
+	return EqualFold(a.t, label, want, got)
+}
+
+// Error is equivalent to calling [Error] with the bound T.
+func (a Asserter) Error(err error, want string) Result {
+	a.t.Helper()
+	return Error(a.t, err, want)
+}
+
+// False is equivalent to calling [False] with the bound T.
+func (a Asserter) False(label string, got bool) Result {
+	a.t.Helper()
+	return False(a.t, label, got)
+}
+
+// ErrorCode is equivalent to calling [ErrorCode] with the bound T.
+func (a Asserter) ErrorCode(err error, want string) Result {
+	a.t.Helper()
+	return ErrorCode(a.t, err, want)
+}
+
+// ErrorCount is equivalent to calling [ErrorCount] with the bound T.
+func (a Asserter) ErrorCount(err error, want int) Result {
+	a.t.Helper()
+	return ErrorCount(a.t, err, want)
+}
+
+// ErrorsContain is equivalent to calling [ErrorsContain] with the bound T.
+func (a Asserter) ErrorsContain(err error, wants ...string) Result {
+	a.t.Helper()
+	return ErrorsContain(a.t, err, wants...)
+}
+
+// ExitsWith is equivalent to calling [ExitsWith] with the bound T.
+func (a Asserter) ExitsWith(want int, cmd *exec.Cmd) Result {
+	a.t.Helper()
+	return ExitsWith(a.t, want, cmd)
+}
+
+// FieldsMatch is equivalent to calling [FieldsMatch] with the bound T.
+func (a Asserter) FieldsMatch(label string, want, got any, fields ...string) Result {
+	a.t.Helper()
+	return FieldsMatch(a.t, label, want, got, fields...)
+}
+
+// FileContains is equivalent to calling [FileContains] with the bound T.
+func (a Asserter) FileContains(fsys fs.FS, name, want string) Result {
+	a.t.Helper()
+	return FileContains(a.t, fsys, name, want)
+}
+
+// FileContent is equivalent to calling [FileContent] with the bound T.
+func (a Asserter) FileContent(fsys fs.FS, name, want string) Result {
+	a.t.Helper()
+	return FileContent(a.t, fsys, name, want)
+}
+
+// FileExists is equivalent to calling [FileExists] with the bound T.
+func (a Asserter) FileExists(fsys fs.FS, name string) Result {
+	a.t.Helper()
+	return FileExists(a.t, fsys, name)
+}
+
+// FileMode is equivalent to calling [FileMode] with the bound T.
+func (a Asserter) FileMode(fsys fs.FS, name string, want fs.FileMode) Result {
+	a.t.Helper()
+	return FileMode(a.t, fsys, name, want)
+}
+
+// GraphQLData is equivalent to calling [GraphQLData] with the bound T.
+func (a Asserter) GraphQLData(resp *http.Response, dest any) Result {
+	a.t.Helper()
+	return GraphQLData(a.t, resp, dest)
+}
+
+// Header is equivalent to calling [Header] with the bound T.
+func (a Asserter) Header(resp *http.Response, key, want string) Result {
+	a.t.Helper()
+	return Header(a.t, resp, key, want)
+}
+
+// HeaderAbsent is equivalent to calling [HeaderAbsent] with the bound T.
+func (a Asserter) HeaderAbsent(resp *http.Response, key string) Result {
+	a.t.Helper()
+	return HeaderAbsent(a.t, resp, key)
+}
+
+// HeaderPresent is equivalent to calling [HeaderPresent] with the bound T.
+func (a Asserter) HeaderPresent(resp *http.Response, key string) Result {
+	a.t.Helper()
+	return HeaderPresent(a.t, resp, key)
+}
+
+// JSONEqual is equivalent to calling [JSONEqual] with the bound T.
+func (a Asserter) JSONEqual(label, want, got string, opts ...JSONOption) Result {
+	a.t.Helper()
+	return JSONEqual(a.t, label, want, got, opts...)
+}
+
+// LoggedError is equivalent to calling [LoggedError] with the bound T.
+func (a Asserter) LoggedError(logs *Logs, want string) Result {
+	a.t.Helper()
+	return LoggedError(a.t, logs, want)
+}
+
+// LoggedWithAttr is equivalent to calling [LoggedWithAttr] with the bound T.
+func (a Asserter) LoggedWithAttr(logs *Logs, key string, value any) Result {
+	a.t.Helper()
+	return LoggedWithAttr(a.t, logs, key, value)
+}
+
+// MatchesJSONSchema is equivalent to calling [MatchesJSONSchema] with the bound
+// T.
+func (a Asserter) MatchesJSONSchema(schema, doc []byte) Result {
+	a.t.Helper()
+	return MatchesJSONSchema(a.t, schema, doc)
+}
+
+// MaxAllocs is equivalent to calling [MaxAllocs] with the bound T.
+func (a Asserter) MaxAllocs(label string, n int, f func()) Result {
+	a.t.Helper()
+	return MaxAllocs(a.t, label, n, f)
+}
+
+// MultipartField is equivalent to calling [MultipartField] with the bound T.
+func (a Asserter) MultipartField(r *http.Request, name, want string) Result {
+	a.t.Helper()
+	return MultipartField(a.t, r, name, want)
+}
+
+// MultipartFile is equivalent to calling [MultipartFile] with the bound T.
+func (a Asserter) MultipartFile(r *http.Request, name string, want FilePart) Result {
+	a.t.Helper()
+	return MultipartFile(a.t, r, name, want)
+}
+
+// NoGoroutineLeak is equivalent to calling [NoGoroutineLeak] with the bound T.
+func (a Asserter) NoGoroutineLeak() func() {
+	a.t.Helper()
+	return NoGoroutineLeak(a.t)
+}
+
+// NoGraphQLErrors is equivalent to calling [NoGraphQLErrors] with the bound T.
+func (a Asserter) NoGraphQLErrors(resp *http.Response) Result {
+	a.t.Helper()
+	return NoGraphQLErrors(a.t, resp)
+}
+
+// NoErrorsLogged is equivalent to calling [NoErrorsLogged] with the bound T.
+func (a Asserter) NoErrorsLogged(logs *Logs) Result {
+	a.t.Helper()
+	return NoErrorsLogged(a.t, logs)
+}
+
+// NotBlank is equivalent to calling [NotBlank] with the bound T.
+func (a Asserter) NotBlank(label string, got string) Result {
+	a.t.Helper()
+	return NotBlank(a.t, label, got)
+}
+
+// NotDeepEqual is equivalent to calling [NotDeepEqual] with the bound T.
+func (a Asserter) NotDeepEqual(label string, unwanted, got any) Result {
+	a.t.Helper()
+	return NotDeepEqual(a.t, label, unwanted, got)
+}
+
+// NotEqual is equivalent to calling [NotEqual] with the bound T. Like
+// [Asserter.Equal], the values are compared as interface values.
+func (a Asserter) NotEqual(label string, unwanted, got any) Result {
+	a.t.Helper()
+	return NotEqual(a.t, label, unwanted, got)
+}
+
+// NumericEqual is equivalent to calling [NumericEqual] with the bound T.
+func (a Asserter) NumericEqual(label, want, got string) Result {
+	a.t.Helper()
+	return NumericEqual(a.t, label, want, got)
+}
+
+// OK is equivalent to calling [OK] with the bound T.
+func (a Asserter) OK(err error) Result {
+	a.t.Helper()
+	return OK(a.t, err)
+}
+
+// ReceivesJSON is equivalent to calling [ReceivesJSON] with the bound T.
+func (a Asserter) ReceivesJSON(conn WebSocketConn, want any, timeout time.Duration) Result {
+	a.t.Helper()
+	return ReceivesJSON(a.t, conn, want, timeout)
+}
+
+// ReceivesMessage is equivalent to calling [ReceivesMessage] with the bound T.
+func (a Asserter) ReceivesMessage(conn WebSocketConn, want string, timeout time.Duration) Result {
+	a.t.Helper()
+	return ReceivesMessage(a.t, conn, want, timeout)
+}
+
+// RedirectsTo is equivalent to calling [RedirectsTo] with the bound T.
+func (a Asserter) RedirectsTo(resp *http.Response, want string) Result {
+	a.t.Helper()
+	return RedirectsTo(a.t, resp, want)
+}
+
+// RedirectsToPrefix is equivalent to calling [RedirectsToPrefix] with the
+// bound T.
+func (a Asserter) RedirectsToPrefix(resp *http.Response, prefix string) Result {
+	a.t.Helper()
+	return RedirectsToPrefix(a.t, resp, prefix)
+}
+
+// RowCount is equivalent to calling [RowCount] with the bound T.
+func (a Asserter) RowCount(label string, rows *sql.Rows, want int) Result {
+	a.t.Helper()
+	return RowCount(a.t, label, rows, want)
+}
+
+// RowsEqual is equivalent to calling [RowsEqual] with the bound T.
+func (a Asserter) RowsEqual(label string, want [][]any, rows *sql.Rows) Result {
+	a.t.Helper()
+	return RowsEqual(a.t, label, want, rows)
+}
+
+// ScanOne is equivalent to calling [ScanOne] with the bound T.
+func (a Asserter) ScanOne(rows *sql.Rows, dest ...any) Result {
+	a.t.Helper()
+	return ScanOne(a.t, rows, dest...)
+}
+
+// ShouldPanic is equivalent to calling [ShouldPanic] with the bound T.
+func (a Asserter) ShouldPanic(f func()) Result {
+	a.t.Helper()
+	return ShouldPanic(a.t, f)
+}
+
+// SliceEqual is equivalent to calling [SliceEqual] with the bound T. Since
+// methods can't have type parameters, want and got must both be slices and are
+// compared element-wise using reflection.
+func (a Asserter) SliceEqual(label string, want, got any) Result {
+	a.t.Helper()
+	if !sliceEqual(reflect.ValueOf(want), reflect.ValueOf(got)) {
+		return fail(a.t, label, "Expected %s to be %v, but got %v.", label, wantValue(want), gotValue(got))
+	}
+	return pass(a.t)
+}
+
+// StatusCode is equivalent to calling [StatusCode] with the bound T.
+func (a Asserter) StatusCode(want int, resp *http.Response) Result {
+	a.t.Helper()
+	return StatusCode(a.t, want, resp)
+}
+
+// TimeEqual is equivalent to calling [TimeEqual] with the bound T.
+func (a Asserter) TimeEqual(label string, want, got time.Time, truncateTo time.Duration) Result {
+	a.t.Helper()
+	return TimeEqual(a.t, label, want, got, truncateTo)
+}
+
+// True is equivalent to calling [True] with the bound T.
+func (a Asserter) True(label string, got bool) Result {
+	a.t.Helper()
+	return True(a.t, label, got)
+}
+
+// ValidEmail is equivalent to calling [ValidEmail] with the bound T.
+func (a Asserter) ValidEmail(label, s string) Result {
+	a.t.Helper()
+	return ValidEmail(a.t, label, s)
+}
+
+// ValidURL is equivalent to calling [ValidURL] with the bound T.
+func (a Asserter) ValidURL(label, s string) Result {
+	a.t.Helper()
+	return ValidURL(a.t, label, s)
+}
+
+// ValidUUID is equivalent to calling [ValidUUID] with the bound T.
+func (a Asserter) ValidUUID(label, s string, version ...int) Result {
+	a.t.Helper()
+	return ValidUUID(a.t, label, s, version...)
+}
+
+// XMLEqual is equivalent to calling [XMLEqual] with the bound T.
+func (a Asserter) XMLEqual(label, want, got string) Result {
+	a.t.Helper()
+	return XMLEqual(a.t, label, want, got)
+}
+
+// YAMLEqual is equivalent to calling [YAMLEqual] with the bound T.
+func (a Asserter) YAMLEqual(label, want, got string) Result {
+	a.t.Helper()
+	return YAMLEqual(a.t, label, want, got)
+}
+
+func sliceEqual(want, got reflect.Value) bool {
+	if want.Kind() != reflect.Slice || got.Kind() != reflect.Slice {
+		return false
+	}
+	if want.Len() != got.Len() {
+		return false
+	}
+	for i := 0; i < want.Len(); i++ {
+		if !want.Index(i).Equal(got.Index(i)) {
+			return false
+		}
+	}
+	return true
+}
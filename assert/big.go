@@ -0,0 +1,80 @@
+package assert
+
+import "math/big"
+
+// BigNumber is a constraint that permits the arbitrary-precision number types
+// from [math/big].
+type BigNumber interface {
+	*big.Int | *big.Float | *big.Rat
+}
+
+// BigEqual validates that two arbitrary-precision numbers are numerically
+// equal as determined by their Cmp methods. Unlike Equal, which compares the
+// pointers, and DeepEqual, which compares internal representations, this
+// treats e.g. two *big.Float values with different precisions but the same
+// value as equal. Two nil values are also considered equal.
+//
+// Failures print the numbers as decimal strings.
+func BigEqual[N BigNumber](t T, label string, want, got N) Result {
+	t.Helper()
+	if want == nil || got == nil {
+		if want != got {
+			return fail(t, label, "Expected %s to be %s, but got %s.", label, wantValue(bigString(want)), gotValue(bigString(got)))
+		}
+		return pass(t)
+	}
+
+	var cmp int
+	switch w := any(want).(type) {
+	case *big.Int:
+		cmp = w.Cmp(any(got).(*big.Int))
+	case *big.Float:
+		cmp = w.Cmp(any(got).(*big.Float))
+	case *big.Rat:
+		cmp = w.Cmp(any(got).(*big.Rat))
+	}
+	if cmp != 0 {
+		return fail(t, label, "Expected %s to be %s, but got %s.", label, wantValue(bigString(want)), gotValue(bigString(got)))
+	}
+	return pass(t)
+}
+
+// NumericEqual validates that two strings represent the same number, so that
+// differences in formatting such as trailing zeros or exponents are ignored,
+// e.g.:
+//
+//	assert.NumericEqual(t, "price", "1.5", "1.50")
+//	assert.NumericEqual(t, "quantity", "1000", "1e3")
+//
+// The strings are parsed exactly, without rounding, using [big.Rat.SetString],
+// so they may be integers, decimals, or fractions such as "3/2".
+func NumericEqual(t T, label, want, got string) Result {
+	t.Helper()
+	wantNum, ok := new(big.Rat).SetString(want)
+	if !ok {
+		return fail(t, label, "Unexpected error parsing wanted %s %q as a number.", label, want)
+	}
+	gotNum, ok := new(big.Rat).SetString(got)
+	if !ok {
+		return fail(t, label, "Expected %s to be a number, but got %q.", label, gotValue(got))
+	}
+	if wantNum.Cmp(gotNum) != 0 {
+		return fail(t, label, "Expected %s to be %s, but got %s.", label, wantValue(want), gotValue(got))
+	}
+	return pass(t)
+}
+
+func bigString[N BigNumber](n N) string {
+	if n == nil {
+		return "<nil>"
+	}
+	switch n := any(n).(type) {
+	case *big.Int:
+		return n.String()
+	case *big.Float:
+		return n.Text('g', -1)
+	case *big.Rat:
+		return n.RatString()
+	}
+	return ""
+}
@@ -0,0 +1,64 @@
+package assert
+
+import "time"
+
+// Closed validates that ch is closed within the timeout. The assertion fails if
+// a value is received from ch instead.
+func Closed[V any](t T, label string, ch <-chan V, timeout time.Duration) Result {
+	t.Helper()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case got, ok := <-ch:
+		if ok {
+			return fail(t, label, "Expected %s to be closed, but received %v.", label, got)
+		}
+		return pass(t)
+	case <-timer.C:
+		return fail(t, label, "Expected %s to be closed within %s, but it wasn't.", label, timeout)
+	}
+}
+
+// Receives validates that the next value received from ch within the timeout
+// is equal to want.
+func Receives[V comparable](t T, label string, ch <-chan V, want V, timeout time.Duration) Result {
+	t.Helper()
+	got, result := receive(t, label, ch, timeout)
+	if !result.OK() {
+		return result
+	}
+	if got != want {
+		return fail(t, label, "Expected %s to be %v, but got %v.", label, wantValue(want), gotValue(got))
+	}
+	return pass(t)
+}
+
+// ReceivesWithin validates that a value is received from ch within the timeout
+// and returns the value for further assertions. The assertion fails if ch is
+// closed before a value is received.
+func ReceivesWithin[V any](t T, label string, ch <-chan V, timeout time.Duration) (V, Result) {
+	t.Helper()
+	got, result := receive(t, label, ch, timeout)
+	if !result.OK() {
+		return got, result
+	}
+	return got, pass(t)
+}
+
+// receive receives the next value from ch. The returned Result is only OK if
+// a value was received, in which case no assertion has been recorded yet.
+func receive[V any](t T, label string, ch <-chan V, timeout time.Duration) (V, Result) {
+	t.Helper()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case got, ok := <-ch:
+		if !ok {
+			return got, fail(t, label, "Expected %s to receive a value, but it was closed.", label)
+		}
+		return got, Result{t: t}
+	case <-timer.C:
+		var zero V
+		return zero, fail(t, label, "Expected %s to receive a value within %s, but it didn't.", label, timeout)
+	}
+}
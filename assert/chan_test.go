@@ -0,0 +1,22 @@
+package assert_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/haleyrc/lib/assert"
+)
+
+func TestReceives_stats(t *testing.T) {
+	stats := assert.TrackStats(t)
+
+	ch := make(chan int, 2)
+	ch <- 42
+	ch <- 42
+	assert.Receives(t, "answer", ch, 42, time.Second)
+	assert.ReceivesWithin(t, "answer", ch, time.Second)
+
+	if got := stats.Executed(); got != 2 {
+		t.Errorf("Expected 2 assertions to be recorded, but got %d.", got)
+	}
+}
@@ -0,0 +1,33 @@
+// Package cmpassert adapts [github.com/google/go-cmp/cmp] for use with the
+// assert package, so that teams already using cmp options and transformers
+// can report differences consistently with other assertions. This lives in a
+// separate package from assert so that projects that don't use cmp don't need
+// to depend on it.
+package cmpassert
+
+import (
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/haleyrc/lib/assert"
+)
+
+// Equal validates that two values are equal according to [cmp.Equal] with the
+// provided options, e.g.:
+//
+//	cmpassert.Equal(t, "user", want, got, cmpopts.IgnoreFields(User{}, "ID"))
+//
+// On failure, the output of [cmp.Diff] is included in the failure output,
+// with lines prefixed by "-" for values only in want and "+" for values only
+// in got.
+func Equal(t assert.T, label string, want, got any, opts ...cmp.Option) assert.Result {
+	t.Helper()
+	diff := cmp.Diff(want, got, opts...)
+	if diff == "" {
+		return assert.Pass(t)
+	}
+	// cmp deliberately randomizes its whitespace to discourage depending on
+	// the exact output, which we undo so that failure output is stable.
+	diff = strings.ReplaceAll(diff, "\u00a0", " ")
+	return assert.Fail(t, label, "Expected %s to be equal, but they weren't (-want +got):\n%s", label, strings.TrimRight(diff, "\n"))
+}
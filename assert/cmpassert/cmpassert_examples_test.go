@@ -0,0 +1,30 @@
+package cmpassert_test
+
+import (
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/haleyrc/lib/assert/cmpassert"
+)
+
+func ExampleEqual() {
+	type Robot struct {
+		Name  string
+		Model string
+	}
+
+	cmpassert.Equal(t, "robot", Robot{"Bender", "Bending Unit 22"}, Robot{"Bender", "Bending Unit 22"})
+	cmpassert.Equal(t, "robot", Robot{"Bender", "Bending Unit 22"}, Robot{"Flexo", "Bending Unit 22"})
+
+	// Options work the same way they do with cmp.
+	cmpassert.Equal(t, "robot", Robot{"Bender", "Bending Unit 22"}, Robot{"BENDER", "bending unit 22"},
+		cmp.Transformer("lower", strings.ToLower),
+	)
+
+	// Output: Expected robot to be equal, but they weren't (-want +got):
+	//   cmpassert_test.Robot{
+	// - 	Name:  "Bender",
+	// + 	Name:  "Flexo",
+	//   	Model: "Bending Unit 22",
+	//   }
+}
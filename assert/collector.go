@@ -0,0 +1,131 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Collector is a T that records failed assertions instead of reporting them
+// immediately. This is useful for table-style validation where you want to see
+// every failure at once rather than stopping at the first one.
+//
+// To create a new Collector, call NewCollector with the T for the current test
+// and pass the Collector to assertions in place of that T:
+//
+//	c := assert.NewCollector(t)
+//	assert.Equal(c, "name", "Bender", robot.Name)
+//	assert.Equal(c, "model", "Bending Unit 22", robot.Model)
+//	c.Report()
+//
+// Failures are reported to the underlying T as a single aggregated summary
+// when Report is called. If the underlying T supports cleanup functions (as
+// [testing.T] does), Report is also called automatically when the test
+// finishes.
+type Collector struct {
+	t T
+
+	mu       sync.Mutex
+	total    int
+	failures []collectedFailure
+}
+
+type collectedFailure struct {
+	label string
+	msg   string
+}
+
+// NewCollector creates a new Collector that reports to t.
+func NewCollector(t T) *Collector {
+	c := &Collector{t: t}
+	if ct, ok := t.(interface{ Cleanup(func()) }); ok {
+		ct.Cleanup(func() { c.Report() })
+	}
+	return c
+}
+
+// Errorf records a failure with no label. Assertions in this package record
+// their failures along with their labels, so this is only called when a test
+// reports a failure directly.
+func (c *Collector) Errorf(format string, args ...any) {
+	c.recordFailure("", fmt.Sprintf(format, args...))
+}
+
+// FailNow reports any failures recorded so far and then stops the test by
+// calling FailNow on the underlying T.
+func (c *Collector) FailNow() {
+	c.t.Helper()
+	c.Report()
+	c.t.FailNow()
+}
+
+// Helper marks the calling function as a test helper on the underlying T.
+func (c *Collector) Helper() {
+	c.t.Helper()
+}
+
+// Log logs to the underlying T.
+func (c *Collector) Log(args ...any) {
+	c.t.Helper()
+	c.t.Log(args...)
+}
+
+// Report reports a summary of all of the failures recorded since the last call
+// to Report to the underlying T. The returned Result is only OK if there were
+// no failures to report.
+func (c *Collector) Report() Result {
+	c.t.Helper()
+
+	c.mu.Lock()
+	total, failures := c.total, c.failures
+	c.total, c.failures = 0, nil
+	c.mu.Unlock()
+
+	if len(failures) == 0 {
+		return Result{t: c.t, failed: false}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Expected %d assertions to pass, but %d failed:", total, len(failures))
+	for _, f := range failures {
+		if f.label == "" {
+			fmt.Fprintf(&sb, "\n\t%s", f.msg)
+			continue
+		}
+		fmt.Fprintf(&sb, "\n\t%s: %s", f.label, f.msg)
+	}
+	c.t.Errorf("%s", sb.String())
+
+	return Result{t: c.t, failed: true}
+}
+
+// Skip reports any failures recorded so far and then skips the test by calling
+// Skip on the underlying T.
+func (c *Collector) Skip(args ...any) {
+	c.t.Helper()
+	c.Report()
+	c.t.Skip(args...)
+}
+
+func (c *Collector) annotateFailure(msg string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.failures) == 0 {
+		return
+	}
+	last := &c.failures[len(c.failures)-1]
+	last.msg += " " + msg
+}
+
+func (c *Collector) recordFailure(label, msg string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.total++
+	c.failures = append(c.failures, collectedFailure{label: label, msg: msg})
+}
+
+func (c *Collector) recordPass() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.total++
+}
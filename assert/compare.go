@@ -0,0 +1,291 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unsafe"
+)
+
+// A Comparer overrides how values of a single type are compared by
+// DeepEqualWith. To create a Comparer, call Using.
+type Comparer struct {
+	typ reflect.Type
+	eq  func(want, got reflect.Value) bool
+}
+
+// Using returns a Comparer that uses eq to compare values of type V wherever
+// they appear in the values passed to DeepEqualWith. Comparers only apply to
+// values whose type is exactly V.
+//
+// Method expressions are often convenient here, e.g.:
+//
+//	assert.DeepEqualWith(t, "event", want, got, assert.Using(time.Time.Equal))
+func Using[V any](eq func(want, got V) bool) Comparer {
+	return Comparer{
+		typ: reflect.TypeFor[V](),
+		eq: func(want, got reflect.Value) bool {
+			// If V is an interface type, a nil value converts to the zero V.
+			w, _ := want.Interface().(V)
+			g, _ := got.Interface().(V)
+			return eq(w, g)
+		},
+	}
+}
+
+// DeepEqualIgnoring validates that two values are "deeply equal" according to
+// the same rules as [reflect.DeepEqual] except that the struct fields named by
+// ignore are skipped. This is useful when some fields such as generated IDs or
+// timestamps are nondeterministic, e.g.:
+//
+//	assert.DeepEqualIgnoring(t, "user", want, got, "ID", "CreatedAt", "Address.ID")
+//
+// Each path is a dot-separated list of field names starting from the top-level
+// struct. Slice, array, map, and pointer indirections don't contribute to the
+// path, so "Items.ID" ignores the ID field of every element of Items.
+func DeepEqualIgnoring(t T, label string, want, got any, ignore ...string) Result {
+	t.Helper()
+	c := deepComparer{ignore: make(map[string]bool, len(ignore)), visited: make(map[visit]bool)}
+	for _, path := range ignore {
+		c.ignore[path] = true
+	}
+	if !c.equal(reflect.ValueOf(want), reflect.ValueOf(got), "") {
+		return failNotEqual(t, label, want, got, fmt.Sprintf(" (ignoring %s)", strings.Join(ignore, ", ")))
+	}
+	return pass(t)
+}
+
+// DeepEqualWith validates that two values are "deeply equal" according to the
+// same rules as [reflect.DeepEqual] except that values with a type matching one
+// of the provided Comparers are compared using that Comparer instead. This
+// makes it possible to correctly compare types such as time.Time that have a
+// notion of equality that differs from their representation.
+//
+// Comparers are applied at any depth, including to unexported struct fields.
+func DeepEqualWith(t T, label string, want, got any, opts ...Comparer) Result {
+	t.Helper()
+	cmps := make(map[reflect.Type]func(want, got reflect.Value) bool, len(opts))
+	for _, opt := range opts {
+		cmps[opt.typ] = opt.eq
+	}
+	c := deepComparer{cmps: cmps, visited: make(map[visit]bool)}
+	if !c.equal(reflect.ValueOf(want), reflect.ValueOf(got), "") {
+		return failNotEqual(t, label, want, got, "")
+	}
+	return pass(t)
+}
+
+// EqualFunc validates that two values are the same according to eq. This is
+// useful for types that aren't comparable or whose notion of equality differs
+// from ==, e.g.:
+//
+//	assert.EqualFunc(t, "created at", want, got, time.Time.Equal)
+func EqualFunc[V any](t T, label string, want, got V, eq func(want, got V) bool) Result {
+	t.Helper()
+	if !eq(want, got) {
+		return fail(t, label, "Expected %s to be %v, but got %v.", label, wantValue(want), gotValue(got))
+	}
+	return pass(t)
+}
+
+// EqualExportedFields validates that two values are "deeply equal" according
+// to the same rules as [reflect.DeepEqual] except that unexported struct fields
+// are ignored at every depth. This makes it possible to compare values that
+// contain internal state such as mutexes or caches, e.g.:
+//
+//	type Account struct {
+//		ID      string
+//		Balance int
+//
+//		mu sync.Mutex
+//	}
+//
+//	assert.EqualExportedFields(t, "account", want, got)
+func EqualExportedFields(t T, label string, want, got any) Result {
+	t.Helper()
+	c := deepComparer{exportedOnly: true, visited: make(map[visit]bool)}
+	if !c.equal(reflect.ValueOf(want), reflect.ValueOf(got), "") {
+		return failNotEqual(t, label, want, got, "")
+	}
+	return pass(t)
+}
+
+// FieldsMatch validates that the listed fields of two structs are "deeply
+// equal" according to the same rules as [reflect.DeepEqual]. All other fields
+// are ignored, which is useful when some fields such as generated IDs or
+// timestamps are nondeterministic, e.g.:
+//
+//	assert.FieldsMatch(t, "user", want, got, "Name", "Email")
+//
+// Each value may be a struct or a pointer to a struct, but the underlying
+// struct types must be the same.
+func FieldsMatch(t T, label string, want, got any, fields ...string) Result {
+	t.Helper()
+
+	wantStruct, gotStruct := structValue(want), structValue(got)
+	if !wantStruct.IsValid() || !gotStruct.IsValid() || wantStruct.Type() != gotStruct.Type() {
+		return fail(t, label, "Expected %s to be structs of the same type, but got %T and %T.", label, want, got)
+	}
+
+	var mismatches []string
+	for _, field := range fields {
+		if _, ok := wantStruct.Type().FieldByName(field); !ok {
+			return fail(t, label, "Expected %s to have field %s, but it didn't.", label, field)
+		}
+		wantField := accessible(wantStruct.FieldByName(field))
+		gotField := accessible(gotStruct.FieldByName(field))
+		c := deepComparer{visited: make(map[visit]bool)}
+		if !c.equal(wantField, gotField, "") {
+			mismatches = append(mismatches, fmt.Sprintf(
+				"Expected %s.%s to be %v, but got %v.",
+				label, field, wantValue(wantField.Interface()), gotValue(gotField.Interface()),
+			))
+		}
+	}
+	if len(mismatches) > 0 {
+		return fail(t, label, "%s", strings.Join(mismatches, "\n"))
+	}
+
+	return pass(t)
+}
+
+// failNotEqual fails with the want and got values formatted in the same way for
+// all of the deep equality assertions. note is appended to the values, e.g. to
+// describe how they were compared.
+func failNotEqual(t T, label string, want, got any, note string) Result {
+	t.Helper()
+	return fail(t, label, "Expected %s to be %v, but got %v%s.", label, wantValue(want), gotValue(got), note)
+}
+
+// structValue returns an addressable struct value for v, which may be a struct
+// or a non-nil pointer to one. If v is neither, the returned value is invalid.
+func structValue(v any) reflect.Value {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	return accessible(rv)
+}
+
+type visit struct {
+	want, got unsafe.Pointer
+	typ       reflect.Type
+}
+
+type deepComparer struct {
+	cmps         map[reflect.Type]func(want, got reflect.Value) bool
+	exportedOnly bool
+	ignore       map[string]bool
+	visited      map[visit]bool
+}
+
+// equal reports whether want and got are deeply equal. path is the dotted
+// path of struct field names leading to the values and is used to match
+// ignored fields.
+func (c deepComparer) equal(want, got reflect.Value, path string) bool {
+	if !want.IsValid() || !got.IsValid() {
+		return want.IsValid() == got.IsValid()
+	}
+	if want.Type() != got.Type() {
+		return false
+	}
+
+	want, got = accessible(want), accessible(got)
+
+	if eq, ok := c.cmps[want.Type()]; ok {
+		return eq(want, got)
+	}
+
+	switch want.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Pointer:
+		if want.IsNil() || got.IsNil() {
+			return want.IsNil() == got.IsNil()
+		}
+		v := visit{want: want.UnsafePointer(), got: got.UnsafePointer(), typ: want.Type()}
+		if c.visited[v] {
+			return true
+		}
+		c.visited[v] = true
+	}
+
+	switch want.Kind() {
+	case reflect.Array:
+		for i := 0; i < want.Len(); i++ {
+			if !c.equal(want.Index(i), got.Index(i), path) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice:
+		if want.Len() != got.Len() {
+			return false
+		}
+		for i := 0; i < want.Len(); i++ {
+			if !c.equal(want.Index(i), got.Index(i), path) {
+				return false
+			}
+		}
+		return true
+	case reflect.Interface:
+		if want.IsNil() || got.IsNil() {
+			return want.IsNil() == got.IsNil()
+		}
+		return c.equal(want.Elem(), got.Elem(), path)
+	case reflect.Pointer:
+		return c.equal(want.Elem(), got.Elem(), path)
+	case reflect.Struct:
+		for i := 0; i < want.NumField(); i++ {
+			field := want.Type().Field(i)
+			if c.exportedOnly && !field.IsExported() {
+				continue
+			}
+			fieldPath := field.Name
+			if path != "" {
+				fieldPath = path + "." + field.Name
+			}
+			if c.ignore[fieldPath] {
+				continue
+			}
+			if !c.equal(want.Field(i), got.Field(i), fieldPath) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if want.Len() != got.Len() {
+			return false
+		}
+		iter := want.MapRange()
+		for iter.Next() {
+			gotValue := got.MapIndex(iter.Key())
+			if !gotValue.IsValid() || !c.equal(iter.Value(), gotValue, path) {
+				return false
+			}
+		}
+		return true
+	case reflect.Func:
+		return want.IsNil() && got.IsNil()
+	default:
+		return reflect.DeepEqual(want.Interface(), got.Interface())
+	}
+}
+
+// accessible returns a version of v that can be passed to Interface and whose
+// fields can be compared, even if v was reached through an unexported struct
+// field.
+func accessible(v reflect.Value) reflect.Value {
+	if !v.CanInterface() {
+		// Values obtained through unexported fields are always addressable since
+		// we make every value addressable below before descending into it.
+		v = reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+	}
+	if !v.CanAddr() {
+		tmp := reflect.New(v.Type()).Elem()
+		tmp.Set(v)
+		v = tmp
+	}
+	return v
+}
@@ -0,0 +1,40 @@
+package assert_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/haleyrc/lib/assert"
+)
+
+func TestDeepEqualWith_nilInterface(t *testing.T) {
+	type result struct {
+		Err error
+	}
+	sameMessage := assert.Using(func(want, got error) bool {
+		if want == nil || got == nil {
+			return want == got
+		}
+		return want.Error() == got.Error()
+	})
+
+	if !assert.DeepEqualWith(silentT{}, "result", result{}, result{}, sameMessage).OK() {
+		t.Error("Expected results with nil errors to be equal, but they weren't.")
+	}
+	if assert.DeepEqualWith(silentT{}, "result", result{}, result{Err: errors.New("x")}, sameMessage).OK() {
+		t.Error("Expected results with nil and non-nil errors not to be equal, but they were.")
+	}
+	if !assert.DeepEqualWith(silentT{}, "result", result{Err: errors.New("x")}, result{Err: errors.New("x")}, sameMessage).OK() {
+		t.Error("Expected results with matching errors to be equal, but they weren't.")
+	}
+}
+
+// silentT is a T that discards failures so that tests can check the Result of
+// an assertion that is expected to fail.
+type silentT struct{}
+
+func (silentT) Errorf(format string, args ...any) {}
+func (silentT) FailNow()                          {}
+func (silentT) Helper()                           {}
+func (silentT) Log(args ...any)                   {}
+func (silentT) Skip(args ...any)                  {}
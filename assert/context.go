@@ -0,0 +1,82 @@
+package assert
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Context returns a context for use in a test. The context is canceled when
+// the test finishes if t supports cleanup functions, and has a deadline
+// matching the test's deadline if t reports one, so that work started by the
+// test stops before the test binary times out. Both are true for
+// [testing.T], e.g.:
+//
+//	func TestFetch(t *testing.T) {
+//		ctx := assert.Context(t)
+//		user, err := client.Fetch(ctx, "bender")
+//		...
+//	}
+//
+// The deadline reported by [testing.T.Deadline] reflects the -timeout flag.
+func Context(t T) context.Context {
+	t.Helper()
+	var deadline time.Time
+	if dt, ok := t.(interface{ Deadline() (time.Time, bool) }); ok {
+		deadline, _ = dt.Deadline()
+	}
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if deadline.IsZero() {
+		ctx, cancel = context.WithCancel(context.Background())
+	} else {
+		ctx, cancel = context.WithDeadline(context.Background(), deadline)
+	}
+	cleanup(t, cancel)
+	return ctx
+}
+
+// ContextAlive validates that ctx has not been canceled and that its deadline,
+// if any, has not passed.
+func ContextAlive(t T, ctx context.Context) Result {
+	t.Helper()
+	if err := ctx.Err(); err != nil {
+		return fail(t, "context", "Expected context to be alive, but got %v.", err)
+	}
+	return pass(t)
+}
+
+// ContextCanceled validates that ctx is done because it was canceled.
+func ContextCanceled(t T, ctx context.Context) Result {
+	t.Helper()
+	return contextDone(t, ctx, context.Canceled)
+}
+
+// ContextDeadlineExceeded validates that ctx is done because its deadline
+// passed.
+func ContextDeadlineExceeded(t T, ctx context.Context) Result {
+	t.Helper()
+	return contextDone(t, ctx, context.DeadlineExceeded)
+}
+
+func contextDone(t T, ctx context.Context, want error) Result {
+	t.Helper()
+	select {
+	case <-ctx.Done():
+	default:
+		return fail(t, "context", "Expected context to be done with %v, but it wasn't done.", want)
+	}
+	if got := ctx.Err(); !errors.Is(got, want) {
+		return fail(t, "context", "Expected context error to be %v, but got %v.", wantValue(want), gotValue(got))
+	}
+	return pass(t)
+}
+
+// cleanup registers f to be called when the test finishes if t supports
+// cleanup functions.
+func cleanup(t T, f func()) {
+	if ct, ok := t.(interface{ Cleanup(func()) }); ok {
+		ct.Cleanup(f)
+	}
+}
@@ -0,0 +1,151 @@
+package assert
+
+import (
+	"encoding/csv"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type csvConfig struct {
+	header                bool
+	ignoreColumnOrder     bool
+	ignoreTrailingNewline bool
+}
+
+// A CSVOption modifies how CSVEqual compares documents.
+type CSVOption func(*csvConfig)
+
+// CSVHeader treats the first record of each document as a header. Failures are
+// reported using column names from the header rather than column numbers.
+func CSVHeader() CSVOption {
+	return func(cfg *csvConfig) {
+		cfg.header = true
+	}
+}
+
+// CSVIgnoreColumnOrder matches columns by their names in the header rather
+// than by position. This option implies CSVHeader.
+func CSVIgnoreColumnOrder() CSVOption {
+	return func(cfg *csvConfig) {
+		cfg.header = true
+		cfg.ignoreColumnOrder = true
+	}
+}
+
+// CSVIgnoreTrailingNewline ignores whether or not the documents end with a
+// newline.
+func CSVIgnoreTrailingNewline() CSVOption {
+	return func(cfg *csvConfig) {
+		cfg.ignoreTrailingNewline = true
+	}
+}
+
+// CSVEqual validates that two strings contain the same CSV records. Both
+// documents are parsed before comparison, so differences in quoting are
+// ignored. Additional differences can be ignored with CSVOptions, e.g.:
+//
+//	assert.CSVEqual(t, "report", want, got, assert.CSVIgnoreColumnOrder())
+//
+// On failure, the first differing record and column are reported.
+func CSVEqual(t T, label, want, got string, opts ...CSVOption) Result {
+	t.Helper()
+
+	var cfg csvConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if !cfg.ignoreTrailingNewline {
+		wantNewline, gotNewline := strings.HasSuffix(want, "\n"), strings.HasSuffix(got, "\n")
+		if wantNewline && !gotNewline {
+			return fail(t, label, "Expected %s to end with a newline, but it didn't.", label)
+		}
+		if !wantNewline && gotNewline {
+			return fail(t, label, "Expected %s to not end with a newline, but it did.", label)
+		}
+	}
+
+	wantRecords, err := readCSV(want)
+	if err != nil {
+		return fail(t, label, "Unexpected error parsing wanted %s as CSV: %v.", label, err)
+	}
+	gotRecords, err := readCSV(got)
+	if err != nil {
+		return fail(t, label, "Expected %s to be valid CSV, but got %v.", label, err)
+	}
+
+	var header []string
+	if cfg.header && len(wantRecords) > 0 {
+		header = wantRecords[0]
+		if len(gotRecords) == 0 {
+			return fail(t, label, "Expected %s to have header %q, but it was empty.", label, header)
+		}
+		if cfg.ignoreColumnOrder {
+			gotRecords, err = reorderColumns(header, gotRecords)
+			if err != nil {
+				return fail(t, label, "Expected %s to have header %q, but %v.", label, header, err)
+			}
+		}
+	}
+
+	if len(wantRecords) != len(gotRecords) {
+		return fail(t, label, "Expected %s to have %d records, but got %d.", label, wantValue(len(wantRecords)), gotValue(len(gotRecords)))
+	}
+	for i := range wantRecords {
+		wantRecord, gotRecord := wantRecords[i], gotRecords[i]
+		if len(wantRecord) != len(gotRecord) {
+			return fail(t, label, "Expected record %d of %s to have %d fields, but got %d.", i+1, label, len(wantRecord), len(gotRecord))
+		}
+		for j := range wantRecord {
+			if wantRecord[j] != gotRecord[j] {
+				column := fmt.Sprintf("column %d", j+1)
+				if header != nil && j < len(header) && i > 0 {
+					column = fmt.Sprintf("column %q", header[j])
+				}
+				return fail(t, label,
+					"Expected record %d, %s of %s to be %q, but got %q.",
+					i+1, column, label, wantValue(wantRecord[j]), gotValue(gotRecord[j]),
+				)
+			}
+		}
+	}
+	return pass(t)
+}
+
+func readCSV(s string) ([][]string, error) {
+	r := csv.NewReader(strings.NewReader(s))
+	r.FieldsPerRecord = -1
+	return r.ReadAll()
+}
+
+// reorderColumns reorders the columns of records so that they match the order
+// in header. The first record of records must be a header containing the same
+// column names.
+func reorderColumns(header []string, records [][]string) ([][]string, error) {
+	gotHeader := records[0]
+	if len(gotHeader) != len(header) {
+		return nil, fmt.Errorf("got %q", gotHeader)
+	}
+	order := make([]int, len(header))
+	for i, name := range header {
+		j := slices.Index(gotHeader, name)
+		if j < 0 {
+			return nil, fmt.Errorf("got %q", gotHeader)
+		}
+		order[i] = j
+	}
+
+	reordered := make([][]string, len(records))
+	for i, record := range records {
+		if len(record) != len(order) {
+			reordered[i] = record
+			continue
+		}
+		reordered[i] = make([]string, len(order))
+		for k, j := range order {
+			reordered[i][k] = record[j]
+		}
+	}
+	return reordered, nil
+}
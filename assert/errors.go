@@ -0,0 +1,130 @@
+package assert
+
+import "strings"
+
+// ErrorCode validates that err or any error it wraps has a Code method
+// returning want. This allows service-layer tests to check for a specific
+// kind of failure without matching on error messages, e.g.:
+//
+//	type NotFoundError struct{ ID string }
+//
+//	func (e NotFoundError) Code() string  { return "NOT_FOUND" }
+//	func (e NotFoundError) Error() string { return e.ID + " not found" }
+//
+//	assert.ErrorCode(t, err, "NOT_FOUND")
+//
+// The chain is unwrapped in the same way as [errors.As], including errors
+// that wrap multiple errors.
+func ErrorCode(t T, err error, want string) Result {
+	t.Helper()
+	if err == nil {
+		return fail(t, "error", "Expected error to not be nil, but it was.")
+	}
+
+	codes := errorCodes(err)
+	for _, code := range codes {
+		if code == want {
+			return pass(t)
+		}
+	}
+	switch len(codes) {
+	case 0:
+		return fail(t, "error", "Expected error to have code %q, but it had no code: %q.", wantValue(want), gotValue(err.Error()))
+	case 1:
+		return fail(t, "error", "Expected error to have code %q, but got %q.", wantValue(want), gotValue(codes[0]))
+	default:
+		return fail(t, "error", "Expected error to have code %q, but got %q.", wantValue(want), gotValue(codes))
+	}
+}
+
+// ErrorCount validates that err is made up of exactly want individual errors.
+// Errors that wrap multiple errors, such as those returned by [errors.Join] or
+// by [fmt.Errorf] with multiple %w verbs, are flattened recursively into their
+// members. Any other non-nil error counts as a single member and a nil error
+// has no members.
+func ErrorCount(t T, err error, want int) Result {
+	t.Helper()
+	members := errorMembers(err)
+	if got := len(members); got != want {
+		return fail(t, "error",
+			"Expected error to have %d members, but got %d: %q.",
+			wantValue(want), gotValue(got), errorStrings(members),
+		)
+	}
+	return pass(t)
+}
+
+// ErrorsContain validates that for each of the desired strings, at least one
+// of the individual errors making up err contains that string. Errors are
+// flattened in the same way as ErrorCount. Asserting on the members
+// individually avoids false positives where a string happens to span the
+// boundary between two members in the combined message.
+func ErrorsContain(t T, err error, wants ...string) Result {
+	t.Helper()
+	if err == nil {
+		return fail(t, "error", "Expected error to not be nil, but it was.")
+	}
+
+	members := errorStrings(errorMembers(err))
+	for _, want := range wants {
+		found := false
+		for _, member := range members {
+			if strings.Contains(member, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fail(t, "error",
+				"Expected error to have a member containing %q, but got %q.",
+				wantValue(want), gotValue(members),
+			)
+		}
+	}
+	return pass(t)
+}
+
+// errorCodes returns the codes of every error in the tree rooted at err that
+// has a Code method, in the order they are found by a depth-first traversal.
+func errorCodes(err error) []string {
+	if err == nil {
+		return nil
+	}
+	var codes []string
+	if coded, ok := err.(interface{ Code() string }); ok {
+		codes = append(codes, coded.Code())
+	}
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		codes = append(codes, errorCodes(u.Unwrap())...)
+	case interface{ Unwrap() []error }:
+		for _, e := range u.Unwrap() {
+			codes = append(codes, errorCodes(e)...)
+		}
+	}
+	return codes
+}
+
+// errorMembers returns the individual errors that make up err.
+func errorMembers(err error) []error {
+	if err == nil {
+		return nil
+	}
+	multi, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+	var members []error
+	for _, e := range multi.Unwrap() {
+		members = append(members, errorMembers(e)...)
+	}
+	return members
+}
+
+func errorStrings(errs []error) []string {
+	strs := make([]string, len(errs))
+	for i, err := range errs {
+		strs[i] = err.Error()
+	}
+	return strs
+}
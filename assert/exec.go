@@ -0,0 +1,49 @@
+package assert
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os/exec"
+)
+
+// ExitsWith runs cmd and validates that it exits with the desired exit code.
+// The standard output and standard error of the command are captured and
+// included in the failure output, which makes this assertion useful for
+// testing command-line programs, e.g.:
+//
+//	assert.ExitsWith(t, 2, exec.Command("./mycli", "--bogus-flag"))
+//
+// If cmd already has Stdout or Stderr set, the output is still written there
+// in addition to being captured.
+func ExitsWith(t T, want int, cmd *exec.Cmd) Result {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = teeWriter(cmd.Stdout, &stdout)
+	cmd.Stderr = teeWriter(cmd.Stderr, &stderr)
+
+	got := 0
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return fail(t, "exit code", "Unexpected error running %s: %v.", cmd, err)
+		}
+		got = exitErr.ExitCode()
+	}
+
+	if got != want {
+		return fail(t, "exit code",
+			"Expected exit code to be %d, but got %d.\nstdout:\n%s\nstderr:\n%s",
+			want, got, stdout.String(), stderr.String(),
+		)
+	}
+	return pass(t)
+}
+
+func teeWriter(w io.Writer, buf *bytes.Buffer) io.Writer {
+	if w == nil {
+		return buf
+	}
+	return io.MultiWriter(w, buf)
+}
@@ -0,0 +1,138 @@
+// Package fixtures contains helpers for loading test data from files. Fixture
+// files are rendered as templates before being decoded, so values such as
+// timestamps and IDs that would otherwise have to be hard-coded can be
+// generated when the fixture is loaded. Errors are reported as fatal failures
+// through [assert.T], so fixtures can be loaded without any error handling.
+package fixtures
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+	"time"
+
+	"github.com/haleyrc/lib/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type config struct {
+	data  any
+	funcs template.FuncMap
+	now   func() time.Time
+}
+
+// An Option modifies how a fixture is loaded.
+type Option func(*config)
+
+// Data sets the value of dot when rendering the fixture, so that fields of
+// data can be referenced in the fixture as e.g. {{.Name}}.
+func Data(data any) Option {
+	return func(cfg *config) {
+		cfg.data = data
+	}
+}
+
+// Funcs adds the functions in funcs to the template functions available when
+// rendering the fixture. Functions in funcs replace the default functions of
+// the same name.
+func Funcs(funcs template.FuncMap) Option {
+	return func(cfg *config) {
+		for name, f := range funcs {
+			cfg.funcs[name] = f
+		}
+	}
+}
+
+// Now sets the time returned by the now template function. This is useful for
+// making fixtures deterministic.
+func Now(now time.Time) Option {
+	return func(cfg *config) {
+		cfg.now = func() time.Time { return now }
+	}
+}
+
+// Load reads and renders the fixture at path and decodes the result into
+// dest, e.g.:
+//
+//	var user User
+//	fixtures.Load(t, "testdata/user.json", &user)
+//
+// The format of the fixture is determined by its extension, which must be one
+// of ".json", ".yaml", or ".yml". Any error causes a fatal failure.
+//
+// In addition to the standard template functions, fixtures can call:
+//
+//	now    the current time formatted according to RFC 3339
+//	uuid   a new random version 4 UUID
+//
+// Fields are decoded from YAML fixtures using their yaml struct tags.
+func Load(t assert.T, path string, dest any, opts ...Option) {
+	t.Helper()
+	b, ok := read(t, path, opts)
+	if !ok {
+		return
+	}
+
+	var err error
+	switch ext := filepath.Ext(path); ext {
+	case ".json":
+		err = json.Unmarshal(b, dest)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(b, dest)
+	default:
+		assert.Fail(t, "fixture", "Expected fixture %s to have a .json, .yaml, or .yml extension, but got %q.", path, ext).Fatal()
+		return
+	}
+	if err != nil {
+		assert.Fail(t, "fixture", "Unexpected error decoding fixture %s: %v.", path, err).Fatal()
+	}
+}
+
+// Read reads and renders the fixture at path and returns the result without
+// decoding it. Fixtures of any format can be read. Any error causes a fatal
+// failure. See Load for the functions available to fixtures.
+func Read(t assert.T, path string, opts ...Option) []byte {
+	t.Helper()
+	b, _ := read(t, path, opts)
+	return b
+}
+
+func read(t assert.T, path string, opts []Option) ([]byte, bool) {
+	t.Helper()
+
+	cfg := config{funcs: template.FuncMap{}, now: time.Now}
+	cfg.funcs["now"] = func() string { return cfg.now().Format(time.RFC3339) }
+	cfg.funcs["uuid"] = newUUID
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		assert.Fail(t, "fixture", "Unexpected error reading fixture %s: %v.", path, err).Fatal()
+		return nil, false
+	}
+	tmpl, err := template.New(filepath.Base(path)).Funcs(cfg.funcs).Option("missingkey=error").Parse(string(src))
+	if err != nil {
+		assert.Fail(t, "fixture", "Unexpected error parsing fixture %s: %v.", path, err).Fatal()
+		return nil, false
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, cfg.data); err != nil {
+		assert.Fail(t, "fixture", "Unexpected error rendering fixture %s: %v.", path, err).Fatal()
+		return nil, false
+	}
+	return buf.Bytes(), true
+}
+
+func newUUID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
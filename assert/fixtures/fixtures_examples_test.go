@@ -0,0 +1,59 @@
+package fixtures_test
+
+import (
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/haleyrc/lib/assert"
+	"github.com/haleyrc/lib/assert/fixtures"
+)
+
+func ExampleLoad() {
+	var robot struct {
+		ID        string    `json:"id"`
+		Name      string    `json:"name"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+	fixtures.Load(t, "testdata/robot.json", &robot, fixtures.Data(map[string]string{"Name": "Bender"}))
+
+	assert.ValidUUID(t, "id", robot.ID, 4)
+	fmt.Println(robot.Name)
+
+	// Output: Bender
+}
+
+func ExampleLoad_yaml() {
+	var robot struct {
+		Name      string    `yaml:"name"`
+		Model     string    `yaml:"model"`
+		CreatedAt time.Time `yaml:"created_at"`
+	}
+	fixtures.Load(t, "testdata/robot.yaml", &robot, fixtures.Now(time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	fmt.Println(robot.Name, robot.Model, robot.CreatedAt.Year())
+
+	// Output: Bender Bending Unit 22 3000
+}
+
+func ExampleLoad_missing() {
+	var robot struct{}
+	fixtures.Load(t, "testdata/missing.json", &robot)
+
+	// Output: Unexpected error reading fixture testdata/missing.json: open testdata/missing.json: no such file or directory.
+}
+
+func ExampleRead() {
+	b := fixtures.Read(t, "testdata/robot.json",
+		fixtures.Data(map[string]string{"Name": "Flexo"}),
+		fixtures.Now(time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC)),
+		fixtures.Funcs(template.FuncMap{"uuid": func() string { return "robot-1" }}),
+	)
+	fmt.Print(string(b))
+
+	// Output: {
+	//   "id": "robot-1",
+	//   "name": "Flexo",
+	//   "created_at": "3000-01-01T00:00:00Z"
+	// }
+}
@@ -0,0 +1,37 @@
+package assert
+
+import "math"
+
+// Float is a constraint that permits any floating-point type.
+type Float interface {
+	~float32 | ~float64
+}
+
+// SliceInDelta validates that two slices of floating-point numbers have the
+// same length and that each pair of elements differs by no more than delta.
+// This is useful for numeric code where exact equality is too strict, e.g.:
+//
+//	assert.SliceInDelta(t, "weights", []float64{0.25, 0.75}, model.Weights(), 1e-9)
+//
+// On failure, the first index whose elements differ by more than delta is
+// reported along with the difference. NaN is only considered equal to NaN.
+func SliceInDelta[S ~[]F, F Float](t T, label string, want, got S, delta F) Result {
+	t.Helper()
+
+	if len(want) != len(got) {
+		return fail(t, label, "Expected %s to have %d elements, but got %d.", label, wantValue(len(want)), gotValue(len(got)))
+	}
+	for i := range want {
+		w, g := float64(want[i]), float64(got[i])
+		if w == g || (math.IsNaN(w) && math.IsNaN(g)) {
+			continue
+		}
+		if diff := math.Abs(w - g); math.IsNaN(diff) || diff > float64(delta) {
+			return fail(t, label,
+				"Expected %s[%d] to be within %v of %v, but got %v (delta %v).",
+				label, i, delta, wantValue(want[i]), gotValue(got[i]), diff,
+			)
+		}
+	}
+	return pass(t)
+}
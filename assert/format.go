@@ -0,0 +1,287 @@
+package assert
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ColorMode controls whether want and got values in failure output are
+// colorized using ANSI escape codes.
+type ColorMode int
+
+const (
+	// ColorAuto colorizes output only if standard output is a terminal. This is
+	// the default.
+	ColorAuto ColorMode = iota
+
+	// ColorAlways always colorizes output.
+	ColorAlways
+
+	// ColorNever never colorizes output.
+	ColorNever
+)
+
+const (
+	ansiGreen = "\x1b[32m"
+	ansiRed   = "\x1b[31m"
+	ansiReset = "\x1b[0m"
+)
+
+var output = struct {
+	mu       sync.RWMutex
+	color    ColorMode
+	compact  bool
+	verbose  bool
+	terminal func() bool
+}{
+	terminal: sync.OnceValue(func() bool {
+		info, err := os.Stdout.Stat()
+		return err == nil && info.Mode()&os.ModeCharDevice != 0
+	}),
+}
+
+func init() {
+	switch strings.ToLower(os.Getenv("ASSERT_COLOR")) {
+	case "always":
+		output.color = ColorAlways
+	case "never":
+		output.color = ColorNever
+	}
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		output.color = ColorNever
+	}
+	switch strings.ToLower(os.Getenv("ASSERT_VERBOSE")) {
+	case "1", "true", "yes":
+		output.verbose = true
+	}
+	switch strings.ToLower(os.Getenv("ASSERT_COMPACT")) {
+	case "1", "true", "yes":
+		output.compact = true
+	}
+}
+
+// SetColor sets whether want and got values in failure output are colorized.
+// The initial mode can also be set with the ASSERT_COLOR environment variable
+// using one of "auto", "always", or "never". Setting the NO_COLOR environment
+// variable disables color regardless of ASSERT_COLOR.
+func SetColor(mode ColorMode) {
+	output.mu.Lock()
+	defer output.mu.Unlock()
+	output.color = mode
+}
+
+// prettyWidth is the length above which the compact representation of a
+// composite value is considered unreadable, causing the value to be
+// pretty-printed instead.
+const prettyWidth = 60
+
+// SetCompact sets whether composite values are always printed on a single line
+// in failure output. By default, structs, maps, and slices whose single-line
+// representation would be too long to read comfortably are pretty-printed
+// across multiple lines with nested values indented and annotated with their
+// types. Compact output can also be enabled by setting the ASSERT_COMPACT
+// environment variable to "1" or "true".
+//
+// SetVerbose takes precedence over SetCompact.
+func SetCompact(compact bool) {
+	output.mu.Lock()
+	defer output.mu.Unlock()
+	output.compact = compact
+}
+
+// SetVerbose sets whether all composite values such as structs, maps, and
+// slices are pretty-printed across multiple lines in failure output,
+// regardless of their size. Verbose output can also be enabled by setting the
+// ASSERT_VERBOSE environment variable to "1" or "true".
+func SetVerbose(verbose bool) {
+	output.mu.Lock()
+	defer output.mu.Unlock()
+	output.verbose = verbose
+}
+
+func colorEnabled() bool {
+	output.mu.RLock()
+	mode := output.color
+	output.mu.RUnlock()
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	default:
+		return output.terminal()
+	}
+}
+
+func verboseEnabled() bool {
+	output.mu.RLock()
+	defer output.mu.RUnlock()
+	return output.verbose
+}
+
+func compactEnabled() bool {
+	output.mu.RLock()
+	defer output.mu.RUnlock()
+	return output.compact
+}
+
+// formatted wraps a value in failure output so that it is formatted according
+// to the current output settings.
+type formatted struct {
+	v     any
+	color string
+}
+
+// wantValue wraps the expected value of an assertion for failure output.
+func wantValue(v any) formatted {
+	return formatted{v: v, color: ansiGreen}
+}
+
+// gotValue wraps the actual value of an assertion for failure output.
+func gotValue(v any) formatted {
+	return formatted{v: v, color: ansiRed}
+}
+
+// Format implements the fmt.Formatter interface.
+func (f formatted) Format(s fmt.State, verb rune) {
+	str := fmt.Sprintf(fmt.FormatString(s, verb), f.v)
+	if verb == 'v' && isComposite(f.v) {
+		if verboseEnabled() || (!compactEnabled() && len(str) > prettyWidth) {
+			str = pretty(f.v)
+		}
+	}
+	if colorEnabled() {
+		str = f.color + str + ansiReset
+	}
+	io.WriteString(s, str)
+}
+
+func isComposite(v any) bool {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		return true
+	}
+	return false
+}
+
+// pretty returns a multi-line representation of v with nested values
+// indented and annotated with their types. Values that refer back to one of
+// their ancestors are printed as <cycle> rather than being followed.
+func pretty(v any) string {
+	p := prettyPrinter{visiting: make(map[visit]bool)}
+	p.write(reflect.ValueOf(v), 0)
+	return p.sb.String()
+}
+
+type prettyPrinter struct {
+	sb       strings.Builder
+	visiting map[visit]bool
+}
+
+var stringerType = reflect.TypeFor[fmt.Stringer]()
+
+func (p *prettyPrinter) write(v reflect.Value, depth int) {
+	indent := strings.Repeat("\t", depth+1)
+	closing := strings.Repeat("\t", depth)
+
+	if !v.IsValid() {
+		p.sb.WriteString("<nil>")
+		return
+	}
+	v = accessible(v)
+
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Pointer:
+		if v.IsNil() {
+			fmt.Fprintf(&p.sb, "%s(nil)", v.Type())
+			return
+		}
+		// Only values on the path from the root are tracked, so values that
+		// are shared but not cyclic are printed in full each time.
+		key := visit{want: v.UnsafePointer(), typ: v.Type()}
+		if p.visiting[key] {
+			p.sb.WriteString("<cycle>")
+			return
+		}
+		p.visiting[key] = true
+		defer delete(p.visiting, key)
+	}
+
+	if v.Kind() == reflect.Struct && v.Type().Implements(stringerType) {
+		fmt.Fprintf(&p.sb, "%s(%q)", v.Type(), v.Interface().(fmt.Stringer).String())
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			p.sb.WriteString("<nil>")
+			return
+		}
+		p.write(v.Elem(), depth)
+	case reflect.Pointer:
+		p.sb.WriteString("&")
+		p.write(v.Elem(), depth)
+	case reflect.Struct:
+		if v.NumField() == 0 {
+			fmt.Fprintf(&p.sb, "%s{}", v.Type())
+			return
+		}
+		fmt.Fprintf(&p.sb, "%s{\n", v.Type())
+		for i := 0; i < v.NumField(); i++ {
+			p.sb.WriteString(indent + v.Type().Field(i).Name + ": ")
+			p.write(v.Field(i), depth+1)
+			p.sb.WriteString(",\n")
+		}
+		p.sb.WriteString(closing + "}")
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			fmt.Fprintf(&p.sb, "%s{}", v.Type())
+			return
+		}
+		fmt.Fprintf(&p.sb, "%s{\n", v.Type())
+		for i := 0; i < v.Len(); i++ {
+			p.sb.WriteString(indent)
+			p.write(v.Index(i), depth+1)
+			p.sb.WriteString(",\n")
+		}
+		p.sb.WriteString(closing + "}")
+	case reflect.Map:
+		if v.Len() == 0 {
+			fmt.Fprintf(&p.sb, "%s{}", v.Type())
+			return
+		}
+		fmt.Fprintf(&p.sb, "%s{\n", v.Type())
+		keys := v.MapKeys()
+		keyStrings := make([]string, len(keys))
+		for i, k := range keys {
+			keyStrings[i] = fmt.Sprintf("%#v", k.Interface())
+		}
+		order := make([]int, len(keys))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return keyStrings[order[i]] < keyStrings[order[j]]
+		})
+		for _, i := range order {
+			p.sb.WriteString(indent + keyStrings[i] + ": ")
+			p.write(v.MapIndex(keys[i]), depth+1)
+			p.sb.WriteString(",\n")
+		}
+		p.sb.WriteString(closing + "}")
+	case reflect.String:
+		fmt.Fprintf(&p.sb, "%q", v.String())
+	default:
+		fmt.Fprintf(&p.sb, "%v", v.Interface())
+	}
+}
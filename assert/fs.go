@@ -0,0 +1,144 @@
+package assert
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+// The assertions in this file operate on an fs.FS so that in-memory
+// filesystems such as fstest.MapFS and directories on disk (via os.DirFS) can
+// be tested in the same way.
+
+// DirEqual validates that two directory trees contain the same files with the
+// same contents. Files missing from got, extra files in got, and files whose
+// contents differ are all reported, which makes this assertion useful for
+// testing code generators and exporters against a directory of expected
+// output, e.g.:
+//
+//	assert.DirEqual(t, os.DirFS("testdata/want"), os.DirFS(outDir))
+//
+// Only regular files are compared, so empty directories are ignored.
+func DirEqual(t T, want, got fs.FS) Result {
+	t.Helper()
+
+	wantFiles, err := readTree(want)
+	if err != nil {
+		return fail(t, "directories", "Unexpected error reading wanted directory: %v.", err)
+	}
+	gotFiles, err := readTree(got)
+	if err != nil {
+		return fail(t, "directories", "Unexpected error reading directory: %v.", err)
+	}
+
+	var problems []string
+	for _, name := range sortedKeys(wantFiles) {
+		gotContent, ok := gotFiles[name]
+		switch {
+		case !ok:
+			problems = append(problems, "missing: "+name)
+		case !bytes.Equal(wantFiles[name], gotContent):
+			problems = append(problems, "different: "+name)
+		}
+	}
+	for _, name := range sortedKeys(gotFiles) {
+		if _, ok := wantFiles[name]; !ok {
+			problems = append(problems, "extra: "+name)
+		}
+	}
+
+	if len(problems) > 0 {
+		return fail(t, "directories",
+			"Expected directories to be equal, but they weren't:\n\t%s",
+			strings.Join(problems, "\n\t"),
+		)
+	}
+	return pass(t)
+}
+
+// FileContains validates that the named file exists in fsys and that its
+// contents contain the desired string.
+func FileContains(t T, fsys fs.FS, name, want string) Result {
+	t.Helper()
+	b, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return fail(t, name, "Unexpected error reading %s: %v.", name, err)
+	}
+	if got := string(b); !strings.Contains(got, want) {
+		return fail(t, name, "Expected %s to contain %q, but got %q.", name, wantValue(want), gotValue(got))
+	}
+	return pass(t)
+}
+
+// FileContent validates that the named file exists in fsys and that its
+// contents are exactly the desired string.
+func FileContent(t T, fsys fs.FS, name, want string) Result {
+	t.Helper()
+	b, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return fail(t, name, "Unexpected error reading %s: %v.", name, err)
+	}
+	if got := string(b); got != want {
+		return fail(t, name, "Expected %s to contain exactly %q, but got %q.", name, wantValue(want), gotValue(got))
+	}
+	return pass(t)
+}
+
+// FileExists validates that the named file exists in fsys. The file may be a
+// regular file or a directory.
+func FileExists(t T, fsys fs.FS, name string) Result {
+	t.Helper()
+	if _, err := fs.Stat(fsys, name); err != nil {
+		return fail(t, name, "Expected %s to exist, but got %v.", name, err)
+	}
+	return pass(t)
+}
+
+// FileMode validates that the permission bits of the named file in fsys match
+// the desired permissions. Only the permission bits of want and of the file
+// mode are compared, so the file type is ignored.
+func FileMode(t T, fsys fs.FS, name string, want fs.FileMode) Result {
+	t.Helper()
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return fail(t, name, "Unexpected error reading %s: %v.", name, err)
+	}
+	if got := info.Mode().Perm(); got != want.Perm() {
+		return fail(t, name, "Expected %s to have mode %s, but got %s.", name, want.Perm(), got)
+	}
+	return pass(t)
+}
+
+// readTree returns the contents of every regular file in fsys keyed by path.
+func readTree(fsys fs.FS) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		b, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", path, err)
+		}
+		files[path] = b
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,141 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// updateGoldenEnv is the environment variable that makes golden assertions
+// update their golden files rather than compare against them.
+const updateGoldenEnv = "UPDATE_GOLDEN"
+
+// updating reports whether golden files should be updated rather than
+// compared. It is true if updateGoldenEnv is set to a true value or if the test
+// binary defines an -update flag that is set. The flag is looked up on each
+// call rather than registered by this package, since registering it would
+// panic in any test package that defines its own.
+func updating() bool {
+	if v, err := strconv.ParseBool(os.Getenv(updateGoldenEnv)); err == nil && v {
+		return true
+	}
+	if f := flag.Lookup("update"); f != nil {
+		v, err := strconv.ParseBool(f.Value.String())
+		return err == nil && v
+	}
+	return false
+}
+
+// BodyGolden validates that the body of the provided response matches the
+// contents of the golden file at path, e.g.:
+//
+//	assert.BodyGolden(t, resp, "testdata/list_users.json")
+//
+// If the response has a JSON content type, both the body and the golden file
+// are normalized before comparison by sorting object keys and re-indenting,
+// so that differences in formatting and key order are ignored.
+//
+// Setting the UPDATE_GOLDEN environment variable to a true value such as "1",
+// or running the tests with an -update flag if the test package defines one,
+// writes the body to the golden file instead of comparing against it,
+// creating the file and any missing parent directories as needed. As with
+// BodyContains, the body can still be read by subsequent assertions.
+func BodyGolden[R Response](t T, r R, path string) Result {
+	t.Helper()
+	resp := response(r)
+	got, err := readBody(resp)
+	if err != nil {
+		return fail(t, "body", "Unexpected error reading body: %v.", err)
+	}
+
+	isJSON := isJSONContentType(resp.Header.Get("Content-Type"))
+	if isJSON {
+		if got, err = normalizeJSON(got); err != nil {
+			return fail(t, "body", "Expected body to be valid JSON, but got %v.", err)
+		}
+	}
+
+	if updating() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fail(t, "body", "Unexpected error updating golden file %s: %v.", path, err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			return fail(t, "body", "Unexpected error updating golden file %s: %v.", path, err)
+		}
+		return pass(t)
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fail(t, "body", "Expected golden file %s to exist, but it didn't. Run the tests with UPDATE_GOLDEN=1 to create it.", path)
+	}
+	if err != nil {
+		return fail(t, "body", "Unexpected error reading golden file %s: %v.", path, err)
+	}
+	if isJSON {
+		if want, err = normalizeJSON(want); err != nil {
+			return fail(t, "body", "Unexpected error parsing golden file %s as JSON: %v.", path, err)
+		}
+	}
+
+	if !bytes.Equal(want, got) {
+		wantLines, gotLines := strings.Split(string(want), "\n"), strings.Split(string(got), "\n")
+		for i := 0; ; i++ {
+			var wantLine, gotLine string
+			if i < len(wantLines) {
+				wantLine = wantLines[i]
+			}
+			if i < len(gotLines) {
+				gotLine = gotLines[i]
+			}
+			if wantLine != gotLine || i >= len(wantLines) || i >= len(gotLines) {
+				return fail(t, "body",
+					"Expected line %d of body to be %q, but got %q. Run the tests with UPDATE_GOLDEN=1 to update %s.",
+					i+1, wantValue(wantLine), gotValue(gotLine), path,
+				)
+			}
+		}
+	}
+	return pass(t)
+}
+
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// normalizeJSON returns a copy of the JSON document in b with a trailing
+// newline in which object keys are sorted and consistently indented, so that
+// documents that differ only in formatting or key order are identical. Numbers
+// are written exactly as they appear in b.
+func normalizeJSON(b []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("invalid character after top-level value")
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
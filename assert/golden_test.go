@@ -0,0 +1,54 @@
+package assert_test
+
+import (
+	"flag"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/haleyrc/lib/assert"
+)
+
+// Test packages commonly define their own -update flag for golden files, which
+// must not conflict with the assert package.
+var update = flag.Bool("update", false, "update golden files")
+
+func TestBodyGolden_update(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "robot.txt")
+	rec := httptest.NewRecorder()
+	rec.WriteString("Bender")
+
+	*update = true
+	defer func() { *update = false }()
+	assert.BodyGolden(t, rec, path)
+
+	got, err := os.ReadFile(path)
+	assert.OK(t, err).Fatal()
+	assert.Equal(t, "golden file", "Bender", string(got))
+}
+
+func TestBodyGolden_updateEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "robot.txt")
+	rec := httptest.NewRecorder()
+	rec.WriteString("Bender")
+
+	t.Setenv("UPDATE_GOLDEN", "1")
+	assert.BodyGolden(t, rec, path)
+
+	got, err := os.ReadFile(path)
+	assert.OK(t, err).Fatal()
+	assert.Equal(t, "golden file", "Bender", string(got))
+}
+
+func TestBodyGolden_jsonKeyOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "robot.json")
+	err := os.WriteFile(path, []byte("{\n  \"name\": \"Bender\",\n  \"serial\": 2716057,\n  \"model\": {\"series\": 22, \"make\": \"Mom's Friendly Robot Company\"}\n}\n"), 0o644)
+	assert.OK(t, err).Fatal()
+
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Type", "application/json")
+	rec.WriteString(`{"model":{"make":"Mom's Friendly Robot Company","series":22},"serial":2716057,"name":"Bender"}`)
+
+	assert.BodyGolden(t, rec, path)
+}
@@ -0,0 +1,118 @@
+package assert
+
+import (
+	"bytes"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// goroutineLeakTimeout is how long NoGoroutineLeak waits for new goroutines to
+// exit before reporting them as leaked.
+const goroutineLeakTimeout = time.Second
+
+// NoGoroutineLeak takes a snapshot of the running goroutines and returns a
+// function that validates that no new goroutines are still running when it is
+// called. The returned function is meant to be deferred or registered as a
+// cleanup function at the start of a test, e.g.:
+//
+//	func TestWorker(t *testing.T) {
+//		t.Cleanup(assert.NoGoroutineLeak(t))
+//		...
+//	}
+//
+// Since goroutines often take a moment to exit after being signaled, the
+// check waits briefly for new goroutines to finish before failing. Goroutines
+// started by the runtime and the testing package are ignored. On failure, the
+// stacks of the leaked goroutines are included in the failure output.
+func NoGoroutineLeak(t T) func() {
+	t.Helper()
+	before := make(map[string]bool)
+	for _, g := range goroutines() {
+		before[g.id] = true
+	}
+
+	return func() {
+		t.Helper()
+
+		var leaked []goroutine
+		deadline := time.Now().Add(goroutineLeakTimeout)
+		for {
+			leaked = leaked[:0]
+			for _, g := range goroutines() {
+				if !before[g.id] && !g.ignored() {
+					leaked = append(leaked, g)
+				}
+			}
+			if len(leaked) == 0 || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if len(leaked) > 0 {
+			stacks := make([]string, 0, len(leaked))
+			for _, g := range leaked {
+				stacks = append(stacks, g.stack)
+			}
+			fail(t, "goroutines",
+				"Expected no goroutines to leak, but %d did:\n\n%s",
+				len(leaked), strings.Join(stacks, "\n\n"),
+			)
+			return
+		}
+		pass(t)
+	}
+}
+
+type goroutine struct {
+	id    string
+	stack string
+}
+
+// ignoredGoroutines contains fragments of stacks for goroutines that are
+// started by the runtime or the testing package and should never be reported
+// as leaks.
+var ignoredGoroutines = []string{
+	"created by testing.",
+	"testing.tRunner",
+	"testing.(*M).",
+	"os/signal.signal_recv",
+	"runtime.ensureSigM",
+}
+
+func (g goroutine) ignored() bool {
+	for _, s := range ignoredGoroutines {
+		if strings.Contains(g.stack, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// goroutines returns the goroutines that are currently running, other than the
+// calling goroutine.
+func goroutines() []goroutine {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	// The first stack is always the calling goroutine.
+	stacks := bytes.Split(buf, []byte("\n\n"))[1:]
+
+	gs := make([]goroutine, 0, len(stacks))
+	for _, stack := range stacks {
+		header, _, _ := strings.Cut(string(stack), " [")
+		gs = append(gs, goroutine{
+			id:    strings.TrimPrefix(header, "goroutine "),
+			stack: strings.TrimSpace(string(stack)),
+		})
+	}
+	return gs
+}
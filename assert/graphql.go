@@ -0,0 +1,96 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// graphQLResponse is the envelope returned by a GraphQL server.
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []graphQLError  `json:"errors"`
+}
+
+type graphQLError struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path"`
+}
+
+func (e graphQLError) String() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+	path := make([]string, len(e.Path))
+	for i, p := range e.Path {
+		path[i] = fmt.Sprint(p)
+	}
+	return fmt.Sprintf("%s (path %s)", e.Message, strings.Join(path, "."))
+}
+
+// GraphQLData validates that the body of the provided response is a GraphQL
+// response containing data and decodes that data into dest, e.g.:
+//
+//	var data struct {
+//		User struct {
+//			Name string `json:"name"`
+//		} `json:"user"`
+//	}
+//	assert.GraphQLData(t, resp, &data)
+//	assert.Equal(t, "name", "Bender", data.User.Name)
+//
+// Errors in the response don't cause GraphQLData to fail since GraphQL allows
+// partial results. Use NoGraphQLErrors to check for errors.
+func GraphQLData[R Response](t T, r R, dest any) Result {
+	t.Helper()
+	gql, result := readGraphQLResponse(t, r)
+	if !result.OK() {
+		return result
+	}
+	if len(gql.Data) == 0 || bytes.Equal(gql.Data, []byte("null")) {
+		return fail(t, "GraphQL response", "Expected GraphQL response to contain data, but it didn't.")
+	}
+	if err := json.Unmarshal(gql.Data, dest); err != nil {
+		return fail(t, "GraphQL response", "Unexpected error decoding GraphQL data: %v.", err)
+	}
+	return pass(t)
+}
+
+// NoGraphQLErrors validates that the body of the provided response is a
+// GraphQL response without any errors. On failure, every error message is
+// reported along with its path.
+func NoGraphQLErrors[R Response](t T, r R) Result {
+	t.Helper()
+	gql, result := readGraphQLResponse(t, r)
+	if !result.OK() {
+		return result
+	}
+	if len(gql.Errors) > 0 {
+		msgs := make([]string, len(gql.Errors))
+		for i, e := range gql.Errors {
+			msgs[i] = e.String()
+		}
+		return fail(t, "GraphQL response",
+			"Expected no GraphQL errors, but got %d:\n\t%s",
+			len(gql.Errors), strings.Join(msgs, "\n\t"),
+		)
+	}
+	return pass(t)
+}
+
+// readGraphQLResponse decodes the GraphQL envelope from the body of r. The
+// returned Result is only OK if the body could be decoded, in which case no
+// assertion has been recorded yet.
+func readGraphQLResponse[R Response](t T, r R) (graphQLResponse, Result) {
+	t.Helper()
+	var gql graphQLResponse
+	body, err := readBody(response(r))
+	if err != nil {
+		return gql, fail(t, "GraphQL response", "Unexpected error reading body: %v.", err)
+	}
+	if err := json.Unmarshal(body, &gql); err != nil {
+		return gql, fail(t, "GraphQL response", "Expected body to be a GraphQL response, but got %v.", err)
+	}
+	return gql, Result{t: t}
+}
@@ -0,0 +1,29 @@
+// Package grpcassert contains assertions for testing gRPC services and
+// clients. These live in a separate package from assert so that projects that
+// don't use gRPC don't need to depend on it.
+package grpcassert
+
+import (
+	"github.com/haleyrc/lib/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Code validates that err carries a gRPC status with the desired code. The
+// status is extracted using [status.FromError], so errors that wrap a status
+// error are supported. A nil error is treated as having the code OK, and an
+// error that doesn't carry a status is treated as having the code Unknown.
+//
+// On failure, the message and details of the actual status are included in
+// the failure output.
+func Code(t assert.T, err error, want codes.Code) assert.Result {
+	t.Helper()
+	st, _ := status.FromError(err)
+	if got := st.Code(); got != want {
+		return assert.Fail(t, "gRPC code",
+			"Expected gRPC code to be %s, but got %s with message %q and details %v.",
+			want, got, st.Message(), st.Details(),
+		)
+	}
+	return assert.Pass(t)
+}
@@ -0,0 +1,19 @@
+package grpcassert_test
+
+import (
+	"fmt"
+
+	"github.com/haleyrc/lib/assert/grpcassert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func ExampleCode() {
+	err := fmt.Errorf("get robot: %w", status.Error(codes.NotFound, "robot 42 not found"))
+
+	grpcassert.Code(t, err, codes.NotFound)
+	grpcassert.Code(t, nil, codes.OK)
+	grpcassert.Code(t, err, codes.PermissionDenied)
+
+	// Output: Expected gRPC code to be PermissionDenied, but got NotFound with message "get robot: rpc error: code = NotFound desc = robot 42 not found" and details [].
+}
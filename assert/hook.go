@@ -0,0 +1,49 @@
+package assert
+
+import "sync"
+
+var hooks struct {
+	mu     sync.RWMutex
+	nextID int
+	fns    map[int]func(T, string)
+}
+
+// OnFailure registers f to be called after every failed assertion in the
+// process. The hook receives the T that the assertion was made against and the
+// label of the failed assertion, which makes it a convenient place to dump
+// extra diagnostic state such as database rows or goroutine stacks.
+//
+// Hooks are called in an unspecified order. The returned function removes the
+// hook and is typically deferred or passed to t.Cleanup.
+func OnFailure(f func(t T, label string)) (remove func()) {
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+
+	if hooks.fns == nil {
+		hooks.fns = make(map[int]func(T, string))
+	}
+	id := hooks.nextID
+	hooks.nextID++
+	hooks.fns[id] = f
+
+	return func() {
+		hooks.mu.Lock()
+		defer hooks.mu.Unlock()
+		delete(hooks.fns, id)
+	}
+}
+
+func runFailureHooks(t T, label string) {
+	t.Helper()
+
+	hooks.mu.RLock()
+	fns := make([]func(T, string), 0, len(hooks.fns))
+	for _, f := range hooks.fns {
+		fns = append(fns, f)
+	}
+	hooks.mu.RUnlock()
+
+	for _, f := range fns {
+		f(t, label)
+	}
+}
@@ -0,0 +1,327 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// MatchesJSONSchema validates that doc is a JSON document that conforms to the
+// provided JSON Schema. Every violation is reported along with the location of
+// the offending value in the document as a JSON Pointer, which makes this
+// assertion well-suited to API contract tests, e.g.:
+//
+//	assert.MatchesJSONSchema(t, userSchema, rec.Body.Bytes())
+//
+// If the schema itself is invalid, the assertion fails with the compilation
+// error.
+func MatchesJSONSchema(t T, schema, doc []byte) Result {
+	t.Helper()
+
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource("schema.json", bytes.NewReader(schema)); err != nil {
+		return fail(t, "JSON schema", "Unexpected error loading JSON schema: %v.", err)
+	}
+	sch, err := c.Compile("schema.json")
+	if err != nil {
+		return fail(t, "JSON schema", "Unexpected error compiling JSON schema: %v.", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return fail(t, "JSON schema", "Unexpected error decoding JSON document: %v.", err)
+	}
+
+	err = sch.Validate(v)
+	if err == nil {
+		return pass(t)
+	}
+
+	var ve *jsonschema.ValidationError
+	if !errors.As(err, &ve) {
+		return fail(t, "JSON schema", "Unexpected error validating JSON document: %v.", err)
+	}
+
+	violations := schemaViolations(ve)
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].InstanceLocation < violations[j].InstanceLocation
+	})
+
+	var sb strings.Builder
+	sb.WriteString("Expected document to match JSON schema, but it didn't:")
+	for _, leaf := range violations {
+		loc := leaf.InstanceLocation
+		if loc == "" {
+			loc = "/"
+		}
+		fmt.Fprintf(&sb, "\n\t%s: %s", loc, leaf.Message)
+	}
+	return fail(t, "JSON schema", "%s", sb.String())
+}
+
+// schemaViolations returns the leaves of the tree of validation errors rooted
+// at ve, which correspond to the individual violations in the document.
+func schemaViolations(ve *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(ve.Causes) == 0 {
+		return []*jsonschema.ValidationError{ve}
+	}
+	var leaves []*jsonschema.ValidationError
+	for _, cause := range ve.Causes {
+		leaves = append(leaves, schemaViolations(cause)...)
+	}
+	return leaves
+}
+
+type jsonConfig struct {
+	ignore   [][]string
+	wildcard [][]string
+}
+
+// A JSONOption modifies how JSONEqual compares documents.
+type JSONOption func(*jsonConfig)
+
+// JSONIgnore excludes the values at the provided paths from comparison. An
+// ignored value may be missing from either document. Paths use a simple
+// JSONPath syntax consisting of "$" followed by any number of ".key" and
+// "[index]" selectors, where "*" matches any key or index, e.g. "$.id",
+// "$.meta.generatedAt", or "$.items[*].id".
+func JSONIgnore(paths ...string) JSONOption {
+	return func(cfg *jsonConfig) {
+		for _, path := range paths {
+			cfg.ignore = append(cfg.ignore, parseJSONPath(path))
+		}
+	}
+}
+
+// JSONWildcard allows the values at the provided paths to be anything, but
+// unlike JSONIgnore, still requires a value to be present in the document
+// being tested. See JSONIgnore for the path syntax.
+func JSONWildcard(paths ...string) JSONOption {
+	return func(cfg *jsonConfig) {
+		for _, path := range paths {
+			cfg.wildcard = append(cfg.wildcard, parseJSONPath(path))
+		}
+	}
+}
+
+// JSONEqual validates that two strings contain equivalent JSON documents. Both
+// documents are parsed before comparison, so differences in formatting, key
+// order, and number representation are ignored. Nondeterministic values can
+// be excluded from comparison with JSONOptions, e.g.:
+//
+//	assert.JSONEqual(t, "response", want, rec.Body.String(),
+//		assert.JSONWildcard("$.id"),
+//		assert.JSONIgnore("$.meta.generatedAt"),
+//	)
+//
+// On failure, the path to the first differing value is reported.
+func JSONEqual(t T, label, want, got string, opts ...JSONOption) Result {
+	t.Helper()
+
+	var cfg jsonConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	wantDoc, err := decodeJSON(want)
+	if err != nil {
+		return fail(t, label, "Unexpected error parsing wanted %s as JSON: %v.", label, err)
+	}
+	gotDoc, err := decodeJSON(got)
+	if err != nil {
+		return fail(t, label, "Expected %s to be valid JSON, but got %v.", label, err)
+	}
+
+	if msg := cfg.diff(label, nil, wantDoc, gotDoc); msg != "" {
+		return fail(t, label, "%s", msg)
+	}
+	return pass(t)
+}
+
+func decodeJSON(s string) (any, error) {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("unexpected data after top-level value")
+	}
+	return v, nil
+}
+
+// diff returns a message describing the first difference between want and
+// got, which are found at path, or the empty string if they are equivalent.
+func (cfg jsonConfig) diff(label string, path []string, want, got any) string {
+	if matchesJSONPath(cfg.wildcard, path) {
+		return ""
+	}
+
+	switch want := want.(type) {
+	case map[string]any:
+		got, ok := got.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := make(map[string]bool, len(want)+len(got))
+		for k := range want {
+			keys[k] = true
+		}
+		for k := range got {
+			keys[k] = true
+		}
+		for _, k := range sortedKeys(keys) {
+			childPath := append(path[:len(path):len(path)], k)
+			if matchesJSONPath(cfg.ignore, childPath) {
+				continue
+			}
+			wantChild, inWant := want[k]
+			gotChild, inGot := got[k]
+			switch {
+			case !inGot:
+				return fmt.Sprintf("Expected %s to have %s, but it didn't.", label, formatJSONPath(childPath))
+			case !inWant && !matchesJSONPath(cfg.wildcard, childPath):
+				return fmt.Sprintf("Expected %s to not have %s, but got %v.", label, formatJSONPath(childPath), gotValue(jsonString(gotChild)))
+			}
+			if msg := cfg.diff(label, childPath, wantChild, gotChild); msg != "" {
+				return msg
+			}
+		}
+		return ""
+	case []any:
+		got, ok := got.([]any)
+		if !ok {
+			break
+		}
+		if len(want) != len(got) {
+			return fmt.Sprintf(
+				"Expected %s at %s to have %d elements, but got %d.",
+				label, formatJSONPath(path), wantValue(len(want)), gotValue(len(got)),
+			)
+		}
+		for i := range want {
+			childPath := append(path[:len(path):len(path)], "["+strconv.Itoa(i)+"]")
+			if matchesJSONPath(cfg.ignore, childPath) {
+				continue
+			}
+			if msg := cfg.diff(label, childPath, want[i], got[i]); msg != "" {
+				return msg
+			}
+		}
+		return ""
+	case json.Number:
+		if got, ok := got.(json.Number); ok && numbersEqual(want, got) {
+			return ""
+		}
+	default:
+		if want == got {
+			return ""
+		}
+	}
+
+	return fmt.Sprintf(
+		"Expected %s at %s to be %s, but got %s.",
+		label, formatJSONPath(path), wantValue(jsonString(want)), gotValue(jsonString(got)),
+	)
+}
+
+func numbersEqual(want, got json.Number) bool {
+	w, ok := new(big.Rat).SetString(want.String())
+	if !ok {
+		return want == got
+	}
+	g, ok := new(big.Rat).SetString(got.String())
+	return ok && w.Cmp(g) == 0
+}
+
+func jsonString(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// parseJSONPath splits a path such as "$.items[0].id" into its segments, e.g.
+// ["items", "[0]", "id"]. Array indexes keep their brackets so that they can
+// be distinguished from object keys.
+func parseJSONPath(path string) []string {
+	path = strings.TrimPrefix(path, "$")
+	var segments []string
+	for path != "" {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			segments = append(segments, path[:end])
+			path = path[end:]
+		case '[':
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				end = len(path)
+			}
+			inner := path[1:end]
+			if unquoted := strings.Trim(inner, `'"`); unquoted != inner {
+				segments = append(segments, unquoted)
+			} else {
+				segments = append(segments, "["+inner+"]")
+			}
+			path = path[min(end+1, len(path)):]
+		default:
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			segments = append(segments, path[:end])
+			path = path[end:]
+		}
+	}
+	return segments
+}
+
+func matchesJSONPath(patterns [][]string, path []string) bool {
+	for _, pattern := range patterns {
+		if len(pattern) != len(path) {
+			continue
+		}
+		matched := true
+		for i := range pattern {
+			isIndex := strings.HasPrefix(path[i], "[")
+			wildcard := (pattern[i] == "*" && !isIndex) || (pattern[i] == "[*]" && isIndex)
+			if !wildcard && pattern[i] != path[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func formatJSONPath(path []string) string {
+	var sb strings.Builder
+	sb.WriteString("$")
+	for _, segment := range path {
+		if strings.HasPrefix(segment, "[") {
+			sb.WriteString(segment)
+		} else {
+			sb.WriteString("." + segment)
+		}
+	}
+	return sb.String()
+}
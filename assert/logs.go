@@ -0,0 +1,170 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Logs captures structured log records written as newline-separated JSON
+// objects, such as those written by the log package in this module. A Logs is
+// an io.Writer, so it can be passed directly as the output of a logger:
+//
+//	logs := assert.NewLogs()
+//	logger := log.New(log.WithOutput(logs))
+//	svc := NewService(logger)
+//	svc.DoThing(ctx)
+//	assert.NoErrorsLogged(t, logs)
+//
+// A Logs is safe for concurrent use.
+type Logs struct {
+	mu      sync.Mutex
+	partial []byte
+	records []map[string]any
+}
+
+// NewLogs creates a new, empty Logs.
+func NewLogs() *Logs {
+	return &Logs{}
+}
+
+// Records returns the records captured so far. Lines that are not valid JSON
+// objects are ignored.
+func (l *Logs) Records() []map[string]any {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	records := make([]map[string]any, len(l.records))
+	copy(records, l.records)
+	return records
+}
+
+// Write implements the io.Writer interface.
+func (l *Logs) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.partial = append(l.partial, p...)
+	for {
+		i := bytes.IndexByte(l.partial, '\n')
+		if i < 0 {
+			break
+		}
+		line := l.partial[:i]
+		l.partial = l.partial[i+1:]
+
+		var record map[string]any
+		if err := json.Unmarshal(line, &record); err == nil {
+			l.records = append(l.records, record)
+		}
+	}
+	return len(p), nil
+}
+
+// LoggedError validates that at least one record was logged at or above the
+// error level with a message containing the desired string.
+func LoggedError(t T, logs *Logs, want string) Result {
+	t.Helper()
+	var errs []string
+	for _, record := range logs.Records() {
+		if !isError(record) {
+			continue
+		}
+		msg, _ := record["msg"].(string)
+		if strings.Contains(msg, want) {
+			return pass(t)
+		}
+		errs = append(errs, msg)
+	}
+	return fail(t, "logs", "Expected an error to be logged containing %q, but got %q.", wantValue(want), gotValue(errs))
+}
+
+// LoggedWithAttr validates that at least one record was logged with an
+// attribute with the given key and value. Attributes nested in groups can be
+// found by joining the group names and the key with dots, e.g. "http.method".
+// Values are compared after both are converted to JSON, so e.g. an int will
+// match the same number logged as a float64.
+func LoggedWithAttr(t T, logs *Logs, key string, value any) Result {
+	t.Helper()
+
+	want, err := jsonValue(value)
+	if err != nil {
+		return fail(t, "logs", "Unexpected error encoding %v as JSON: %v.", value, err)
+	}
+
+	var found []any
+	for _, record := range logs.Records() {
+		got, ok := lookupAttr(record, key)
+		if !ok {
+			continue
+		}
+		if reflect.DeepEqual(got, want) {
+			return pass(t)
+		}
+		found = append(found, got)
+	}
+	if len(found) == 0 {
+		return fail(t, "logs", "Expected a record to be logged with %s, but none were.", key)
+	}
+	return fail(t, "logs", "Expected a record to be logged with %s=%v, but got %v.", key, wantValue(want), gotValue(found))
+}
+
+// NoErrorsLogged validates that no records were logged at or above the error
+// level, including those logged by Fatal.
+func NoErrorsLogged(t T, logs *Logs) Result {
+	t.Helper()
+	var errs []string
+	for _, record := range logs.Records() {
+		if isError(record) {
+			msg, _ := record["msg"].(string)
+			errs = append(errs, msg)
+		}
+	}
+	if len(errs) > 0 {
+		return fail(t, "logs", "Expected no errors to be logged, but got %q.", errs)
+	}
+	return pass(t)
+}
+
+// isError reports whether record was logged at or above the error level. As
+// well as the level names used by slog, such as "ERROR" and "ERROR+2", this
+// recognizes "FATAL", the name of the level used by Fatal in the log package.
+func isError(record map[string]any) bool {
+	name, _ := record["level"].(string)
+	if name == "FATAL" {
+		return true
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return false
+	}
+	return level >= slog.LevelError
+}
+
+func lookupAttr(record map[string]any, key string) (any, bool) {
+	var v any = record
+	for _, part := range strings.Split(key, ".") {
+		group, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = group[part]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func jsonValue(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
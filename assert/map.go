@@ -0,0 +1,50 @@
+package assert
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MapContains validates that m contains key and that its value is value.
+func MapContains[M ~map[K]V, K, V comparable](t T, label string, m M, key K, value V) Result {
+	t.Helper()
+	got, ok := m[key]
+	if !ok {
+		return fail(t, label, "Expected %s to contain key %v, but it had keys %s.", label, wantValue(key), mapKeys(m))
+	}
+	if got != value {
+		return fail(t, label, "Expected %s[%v] to be %v, but got %v.", label, key, wantValue(value), gotValue(got))
+	}
+	return pass(t)
+}
+
+// MapContainsKey validates that m contains key.
+func MapContainsKey[M ~map[K]V, K comparable, V any](t T, label string, m M, key K) Result {
+	t.Helper()
+	if _, ok := m[key]; !ok {
+		return fail(t, label, "Expected %s to contain key %v, but it had keys %s.", label, wantValue(key), mapKeys(m))
+	}
+	return pass(t)
+}
+
+// MapContainsValue validates that at least one key in m maps to value.
+func MapContainsValue[M ~map[K]V, K, V comparable](t T, label string, m M, value V) Result {
+	t.Helper()
+	for _, got := range m {
+		if got == value {
+			return pass(t)
+		}
+	}
+	return fail(t, label, "Expected %s to contain value %v, but it didn't.", label, wantValue(value))
+}
+
+// mapKeys returns a sorted, human-readable list of the keys in m.
+func mapKeys[M ~map[K]V, K comparable, V any](m M) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, fmt.Sprint(k))
+	}
+	slices.Sort(keys)
+	return "[" + strings.Join(keys, " ") + "]"
+}
@@ -0,0 +1,135 @@
+// Package mock provides a lightweight way to build test doubles that record
+// their calls, along with assertions for verifying those calls.
+//
+// A test double embeds a Recorder and records each call to its methods:
+//
+//	type mailer struct {
+//		mock.Recorder
+//	}
+//
+//	func (m *mailer) Send(to, body string) error {
+//		m.Record("Send", to, body)
+//		return nil
+//	}
+//
+// The test can then make assertions about how the double was used:
+//
+//	mock.CalledWith(t, &m.Recorder, "Send", "bender@planetexpress.com", "Bite my shiny metal...")
+//
+// For testing HTTP clients, Transport provides a ready-made test double for
+// http.RoundTripper.
+package mock
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/haleyrc/lib/assert"
+)
+
+// Call is a single recorded invocation of a method.
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// String returns a representation of the call that resembles Go syntax.
+func (c Call) String() string {
+	return fmt.Sprintf("%s(%s)", c.Method, formatArgs(c.Args))
+}
+
+// Arg returns the argument at index i of call as a V. It panics if the call
+// doesn't have an argument at that index or if the argument isn't a V, which
+// indicates a bug in the test.
+func Arg[V any](call Call, i int) V {
+	if i >= len(call.Args) {
+		panic(fmt.Sprintf("mock: %s has no argument at index %d", call, i))
+	}
+	v, ok := call.Args[i].(V)
+	if !ok {
+		panic(fmt.Sprintf("mock: argument %d of %s is %T, not %s", i, call, call.Args[i], reflect.TypeFor[V]()))
+	}
+	return v
+}
+
+// Recorder records calls to the methods of a test double. The zero value is
+// ready to use and a Recorder is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Calls returns the calls recorded for the named method in the order in which
+// they were made. If method is the blank string, all recorded calls are
+// returned.
+func (r *Recorder) Calls(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var calls []Call
+	for _, call := range r.calls {
+		if method == "" || call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Record records a call to the named method with the provided arguments.
+func (r *Recorder) Record(method string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Reset discards all recorded calls.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
+
+// CalledTimes validates that the named method was called exactly want times.
+func CalledTimes(t assert.T, r *Recorder, method string, want int) assert.Result {
+	t.Helper()
+	if got := len(r.Calls(method)); got != want {
+		return assert.Fail(t, method, "Expected %s to be called %d times, but it was called %d times.", method, want, got)
+	}
+	return assert.Pass(t)
+}
+
+// CalledWith validates that the named method was called at least once with
+// arguments that are "deeply equal" to args according to the same rules as
+// [reflect.DeepEqual].
+func CalledWith(t assert.T, r *Recorder, method string, args ...any) assert.Result {
+	t.Helper()
+	calls := r.Calls(method)
+	for _, call := range calls {
+		if reflect.DeepEqual(call.Args, args) {
+			return assert.Pass(t)
+		}
+	}
+	want := Call{Method: method, Args: args}
+	if len(calls) == 0 {
+		return assert.Fail(t, method, "Expected %s to be called, but it wasn't called.", want)
+	}
+	return assert.Fail(t, method, "Expected %s to be called, but got %v.", want, calls)
+}
+
+// NotCalled validates that the named method was never called.
+func NotCalled(t assert.T, r *Recorder, method string) assert.Result {
+	t.Helper()
+	if calls := r.Calls(method); len(calls) > 0 {
+		return assert.Fail(t, method, "Expected %s to not be called, but got %v.", method, calls)
+	}
+	return assert.Pass(t)
+}
+
+func formatArgs(args []any) string {
+	strs := make([]string, len(args))
+	for i, arg := range args {
+		strs[i] = fmt.Sprintf("%#v", arg)
+	}
+	return strings.Join(strs, ", ")
+}
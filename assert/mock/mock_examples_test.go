@@ -0,0 +1,80 @@
+package mock_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/haleyrc/lib/assert"
+	"github.com/haleyrc/lib/assert/mock"
+)
+
+func ExampleArg() {
+	m := new(mailer)
+	m.Send("bender@planetexpress.com", "Bite my shiny metal...")
+
+	call := m.Calls("Send")[0]
+	assert.Equal(t, "recipient", "bender@planetexpress.com", mock.Arg[string](call, 0))
+
+	// Output:
+}
+
+func ExampleCalledTimes() {
+	m := new(mailer)
+	m.Send("bender@planetexpress.com", "Bite my shiny metal...")
+
+	mock.CalledTimes(t, &m.Recorder, "Send", 1)
+	mock.CalledTimes(t, &m.Recorder, "Send", 2)
+
+	// Output: Expected Send to be called 2 times, but it was called 1 times.
+}
+
+func ExampleCalledWith() {
+	m := new(mailer)
+	mock.CalledWith(t, &m.Recorder, "Send", "bender@planetexpress.com", "Hello")
+
+	m.Send("bender@planetexpress.com", "Bite my shiny metal...")
+	mock.CalledWith(t, &m.Recorder, "Send", "bender@planetexpress.com", "Bite my shiny metal...")
+	mock.CalledWith(t, &m.Recorder, "Send", "bender@planetexpress.com", "Hello")
+
+	// Output: Expected Send("bender@planetexpress.com", "Hello") to be called, but it wasn't called.
+	// Expected Send("bender@planetexpress.com", "Hello") to be called, but got [Send("bender@planetexpress.com", "Bite my shiny metal...")].
+}
+
+func ExampleNotCalled() {
+	m := new(mailer)
+	mock.NotCalled(t, &m.Recorder, "Send")
+
+	m.Send("bender@planetexpress.com", "Bite my shiny metal...")
+	mock.NotCalled(t, &m.Recorder, "Send")
+
+	// Output: Expected Send to not be called, but got [Send("bender@planetexpress.com", "Bite my shiny metal...")].
+}
+
+func ExampleNewTransport() {
+	tr := mock.NewTransport(
+		mock.Response{StatusCode: http.StatusCreated, Body: `{"id":1}`},
+	)
+	client := &http.Client{Transport: tr}
+
+	resp, err := client.Post("https://example.com/robots", "application/json", strings.NewReader(`{"name": "Bender"}`))
+	assert.OK(t, err)
+	assert.StatusCode(t, http.StatusCreated, resp)
+	fmt.Println(resp.Status)
+
+	mock.RequestCount(t, tr, 1)
+	mock.RequestedURL(t, tr, "https://example.com/robots")
+	mock.RequestBodyJSON(t, tr, 0, map[string]string{"name": "Bender"})
+
+	mock.RequestedURL(t, tr, "https://example.com/humans")
+	mock.RequestBodyJSON(t, tr, 0, map[string]string{"name": "Flexo"})
+	mock.RequestBodyJSON(t, tr, -1, map[string]string{"name": "Bender"})
+
+	_, err = client.Get("https://example.com/robots/1")
+	assert.Error(t, err, "no scripted response")
+
+	// Output: 201 Created
+	// Expected a request to https://example.com/humans, but got [https://example.com/robots].
+	// Expected request body to be {"name":"Flexo"}, but got {"name": "Bender"}.
+	// Expected request index to be non-negative, but got -1.
+}
@@ -0,0 +1,42 @@
+package mock_test
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/haleyrc/lib/assert/mock"
+)
+
+// N.B.: These definitions need to exist in a separate file from the testable
+// examples to prevent the documentation from including them in every example
+// block.
+
+var t mockT
+
+type mockT struct{}
+
+func (mockT) Errorf(format string, args ...any) {
+	fmt.Fprintf(os.Stdout, format, args...)
+	fmt.Fprintln(os.Stdout)
+}
+
+func (mockT) FailNow() {}
+
+func (mockT) Helper() {}
+
+func (mockT) Log(args ...any) {
+	fmt.Fprintln(os.Stdout, args...)
+}
+
+func (mockT) Skip(args ...any) {
+	fmt.Fprintln(os.Stdout, args...)
+}
+
+type mailer struct {
+	mock.Recorder
+}
+
+func (m *mailer) Send(to, body string) error {
+	m.Record("Send", to, body)
+	return nil
+}
@@ -0,0 +1,196 @@
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/haleyrc/lib/assert"
+)
+
+// ErrNoResponse is returned by Transport.RoundTrip when a request is made but
+// there are no scripted responses left.
+var ErrNoResponse = errors.New("mock: no scripted response")
+
+// Response is a scripted response returned by a Transport.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       string
+
+	// If Err is non-nil, RoundTrip returns it instead of a response.
+	Err error
+}
+
+// Transport is an http.RoundTripper that records outgoing requests and returns
+// scripted responses. It is meant to be used as the Transport of an
+// http.Client passed to the code under test, e.g.:
+//
+//	tr := mock.NewTransport(mock.Response{StatusCode: http.StatusOK, Body: `{"id":1}`})
+//	client := NewAPIClient(&http.Client{Transport: tr})
+//
+// A Transport is safe for concurrent use.
+type Transport struct {
+	mu        sync.Mutex
+	responses []Response
+	requests  []recordedRequest
+}
+
+type recordedRequest struct {
+	req  *http.Request
+	body []byte
+}
+
+// NewTransport creates a new Transport that returns the provided responses in
+// order, one per request.
+func NewTransport(responses ...Response) *Transport {
+	return &Transport{responses: responses}
+}
+
+// Requests returns copies of the requests made through the transport in the
+// order in which they were made. The body of each request can be read
+// independently of the others.
+func (tr *Transport) Requests() []*http.Request {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	reqs := make([]*http.Request, len(tr.requests))
+	for i, r := range tr.requests {
+		req := r.req.Clone(r.req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(r.body))
+		reqs[i] = req
+	}
+	return reqs
+}
+
+// Respond adds responses to the end of the script.
+func (tr *Transport) Respond(responses ...Response) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	tr.responses = append(tr.responses, responses...)
+}
+
+// RoundTrip implements the http.RoundTripper interface. The request is
+// recorded and the next scripted response is returned. If there are no
+// scripted responses left, RoundTrip returns ErrNoResponse.
+func (tr *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = b
+	}
+
+	tr.mu.Lock()
+	tr.requests = append(tr.requests, recordedRequest{req: req, body: body})
+	if len(tr.responses) == 0 {
+		tr.mu.Unlock()
+		return nil, ErrNoResponse
+	}
+	scripted := tr.responses[0]
+	tr.responses = tr.responses[1:]
+	tr.mu.Unlock()
+
+	if scripted.Err != nil {
+		return nil, scripted.Err
+	}
+
+	header := scripted.Header
+	if header == nil {
+		header = http.Header{}
+	}
+	statusCode := scripted.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	return &http.Response{
+		Status:        strings.TrimSpace(strconv.Itoa(statusCode) + " " + http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(scripted.Body))),
+		ContentLength: int64(len(scripted.Body)),
+		Request:       req,
+	}, nil
+}
+
+// RequestBodyJSON validates that the body of the request at index i is a JSON
+// document equivalent to the JSON encoding of want. Both documents are decoded
+// before comparison, so formatting and key order are ignored.
+func RequestBodyJSON(t assert.T, tr *Transport, i int, want any) assert.Result {
+	t.Helper()
+
+	tr.mu.Lock()
+	n := len(tr.requests)
+	var body []byte
+	if i >= 0 && i < n {
+		body = tr.requests[i].body
+	}
+	tr.mu.Unlock()
+
+	if i < 0 {
+		return assert.Fail(t, "request body", "Expected request index to be non-negative, but got %d.", i)
+	}
+	if i >= n {
+		return assert.Fail(t, "request body", "Expected request %d to be made, but only %d requests were made.", i, n)
+	}
+
+	wantJSON, err := json.Marshal(want)
+	if err != nil {
+		return assert.Fail(t, "request body", "Unexpected error encoding wanted body: %v.", err)
+	}
+	var wantValue, gotValue any
+	if err := json.Unmarshal(wantJSON, &wantValue); err != nil {
+		return assert.Fail(t, "request body", "Unexpected error decoding wanted body: %v.", err)
+	}
+	if err := json.Unmarshal(body, &gotValue); err != nil {
+		return assert.Fail(t, "request body", "Expected request body to be JSON, but got %q.", body)
+	}
+
+	if !reflect.DeepEqual(wantValue, gotValue) {
+		return assert.Fail(t, "request body", "Expected request body to be %s, but got %s.", wantJSON, body)
+	}
+	return assert.Pass(t)
+}
+
+// RequestCount validates that exactly want requests were made through the
+// transport.
+func RequestCount(t assert.T, tr *Transport, want int) assert.Result {
+	t.Helper()
+	tr.mu.Lock()
+	got := len(tr.requests)
+	tr.mu.Unlock()
+	if got != want {
+		return assert.Fail(t, "requests", "Expected %d requests to be made, but got %d.", want, got)
+	}
+	return assert.Pass(t)
+}
+
+// RequestedURL validates that at least one request was made to the desired
+// URL.
+func RequestedURL(t assert.T, tr *Transport, want string) assert.Result {
+	t.Helper()
+	tr.mu.Lock()
+	urls := make([]string, len(tr.requests))
+	for i, r := range tr.requests {
+		urls[i] = r.req.URL.String()
+	}
+	tr.mu.Unlock()
+
+	for _, url := range urls {
+		if url == want {
+			return assert.Pass(t)
+		}
+	}
+	return assert.Fail(t, "requests", "Expected a request to %s, but got %v.", want, urls)
+}
@@ -0,0 +1,153 @@
+package assert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+// A FilePart describes a file uploaded as part of a multipart form. It is
+// used as the wanted value for MultipartFile.
+type FilePart struct {
+	Filename    string
+	ContentType string
+	Content     string
+}
+
+// multipartPart is a fully-read part of a multipart body.
+type multipartPart struct {
+	name        string
+	filename    string
+	contentType string
+	content     string
+}
+
+// MultipartField validates that the body of the provided request is a
+// multipart form containing a non-file field with the given name and value.
+// This is useful for testing clients that build upload requests, e.g. by
+// recording requests sent to an [httptest.Server]:
+//
+//	assert.MultipartField(t, req, "title", "Bender's Big Score")
+//
+// If the form contains multiple fields with the same name, the first is used.
+// The body is read in full and then replaced with a fresh reader over the same
+// bytes, so it can still be read by subsequent assertions.
+func MultipartField(t T, r *http.Request, name, want string) Result {
+	t.Helper()
+	parts, err := readMultipart(r)
+	if err != nil {
+		return fail(t, "form", "Unexpected error reading multipart body: %v.", err)
+	}
+	for _, part := range parts {
+		if part.name != name || part.filename != "" {
+			continue
+		}
+		if part.content != want {
+			return fail(t, "form", "Expected form field %s to be %q, but got %q.", name, wantValue(want), gotValue(part.content))
+		}
+		return pass(t)
+	}
+	return fail(t, "form", "Expected form to have field %s, but it didn't.", name)
+}
+
+// MultipartFile validates that the body of the provided request is a
+// multipart form containing a file part with the given field name whose
+// filename, content type, and content all match want, e.g.:
+//
+//	assert.MultipartFile(t, req, "avatar", assert.FilePart{
+//		Filename:    "bender.png",
+//		ContentType: "image/png",
+//		Content:     string(avatar),
+//	})
+//
+// If the form contains multiple files with the same field name, the first is
+// used. The body is read in full and then replaced with a fresh reader over
+// the same bytes, so it can still be read by subsequent assertions.
+func MultipartFile(t T, r *http.Request, name string, want FilePart) Result {
+	t.Helper()
+	parts, err := readMultipart(r)
+	if err != nil {
+		return fail(t, "form", "Unexpected error reading multipart body: %v.", err)
+	}
+	for _, part := range parts {
+		if part.name != name || part.filename == "" {
+			continue
+		}
+		var mismatches []string
+		if part.filename != want.Filename {
+			mismatches = append(mismatches, fmt.Sprintf(
+				"Expected form file %s to have filename %q, but got %q.",
+				name, wantValue(want.Filename), gotValue(part.filename),
+			))
+		}
+		if part.contentType != want.ContentType {
+			mismatches = append(mismatches, fmt.Sprintf(
+				"Expected form file %s to have content type %s, but got %s.",
+				name, wantValue(want.ContentType), gotValue(part.contentType),
+			))
+		}
+		if part.content != want.Content {
+			mismatches = append(mismatches, fmt.Sprintf(
+				"Expected form file %s to have content %q, but got %q.",
+				name, wantValue(want.Content), gotValue(part.content),
+			))
+		}
+		if len(mismatches) > 0 {
+			return fail(t, "form", "%s", strings.Join(mismatches, "\n"))
+		}
+		return pass(t)
+	}
+	return fail(t, "form", "Expected form to have file %s, but it didn't.", name)
+}
+
+// readMultipart reads every part of the multipart body of r. The body is
+// replaced with a fresh reader over the same bytes.
+func readMultipart(r *http.Request) ([]multipartPart, error) {
+	mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("parse content type: %w", err)
+	}
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		return nil, fmt.Errorf("content type %s is not multipart", mediaType)
+	}
+	if params["boundary"] == "" {
+		return nil, errors.New("content type has no boundary")
+	}
+
+	var body []byte
+	if r.Body != nil {
+		body, err = io.ReadAll(r.Body)
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var parts []multipartPart
+	mr := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			return parts, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(p)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, multipartPart{
+			name:        p.FormName(),
+			filename:    p.FileName(),
+			contentType: p.Header.Get("Content-Type"),
+			content:     string(content),
+		})
+	}
+}
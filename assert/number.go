@@ -0,0 +1,35 @@
+package assert
+
+// Number is a constraint that permits any integer or floating-point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Negative validates that got is less than zero.
+func Negative[N Number](t T, label string, got N) Result {
+	t.Helper()
+	if !(got < 0) {
+		return fail(t, label, "Expected %s to be negative, but got %v.", label, gotValue(got))
+	}
+	return pass(t)
+}
+
+// NonNegative validates that got is greater than or equal to zero.
+func NonNegative[N Number](t T, label string, got N) Result {
+	t.Helper()
+	if !(got >= 0) {
+		return fail(t, label, "Expected %s to be non-negative, but got %v.", label, gotValue(got))
+	}
+	return pass(t)
+}
+
+// Positive validates that got is greater than zero.
+func Positive[N Number](t T, label string, got N) Result {
+	t.Helper()
+	if !(got > 0) {
+		return fail(t, label, "Expected %s to be positive, but got %v.", label, gotValue(got))
+	}
+	return pass(t)
+}
@@ -0,0 +1,308 @@
+package assert
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"time"
+)
+
+const (
+	defaultPropertyIterations = 100
+	maxShrinkSteps            = 1000
+)
+
+type propertyConfig struct {
+	iterations int
+	seed       uint64
+	seeded     bool
+	gens       map[reflect.Type]func(r *rand.Rand, size int) reflect.Value
+}
+
+// A PropertyOption modifies how Property checks a property.
+type PropertyOption func(*propertyConfig)
+
+// Iterations sets the number of random inputs Property checks. The default is
+// 100.
+func Iterations(n int) PropertyOption {
+	return func(cfg *propertyConfig) {
+		cfg.iterations = n
+	}
+}
+
+// Seed sets the seed used to generate inputs, which makes a failing run
+// reproducible. By default, a random seed is used and is included in the
+// failure output.
+func Seed(seed uint64) PropertyOption {
+	return func(cfg *propertyConfig) {
+		cfg.seed = seed
+		cfg.seeded = true
+	}
+}
+
+// Generator overrides how values of type V are generated by Property. The
+// generator is used wherever V appears in the input, including as a struct
+// field or slice element. size grows over the course of a run and should be
+// used to bound the size of the generated value. Values from a Generator are
+// not shrunk.
+func Generator[V any](gen func(r *rand.Rand, size int) V) PropertyOption {
+	return func(cfg *propertyConfig) {
+		cfg.gens[reflect.TypeFor[V]()] = func(r *rand.Rand, size int) reflect.Value {
+			return reflect.ValueOf(gen(r, size))
+		}
+	}
+}
+
+// Property validates that pred holds for randomly generated inputs of type A,
+// e.g.:
+//
+//	assert.Property(t, "reverse is an involution", func(s []int) bool {
+//		return slices.Equal(reverse(reverse(s)), s)
+//	})
+//
+// Inputs are generated using reflection, so A can be any combination of
+// booleans, numbers, strings, slices, arrays, maps, pointers, and structs
+// with exported fields. Use a struct to check properties of multiple values,
+// and Generator to control how values of a particular type are generated.
+//
+// If pred returns false for an input, the input is repeatedly simplified
+// (e.g. numbers moved toward zero and elements removed from slices) for as
+// long as pred still fails, and the smallest failing input is reported along
+// with the seed needed to reproduce the failure.
+func Property[A any](t T, label string, pred func(A) bool, opts ...PropertyOption) Result {
+	t.Helper()
+
+	cfg := propertyConfig{
+		iterations: defaultPropertyIterations,
+		gens:       make(map[reflect.Type]func(r *rand.Rand, size int) reflect.Value),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if !cfg.seeded {
+		cfg.seed = uint64(time.Now().UnixNano())
+	}
+
+	r := rand.New(rand.NewPCG(cfg.seed, cfg.seed))
+	typ := reflect.TypeFor[A]()
+	holds := func(v reflect.Value) bool { return pred(v.Interface().(A)) }
+
+	for i := 0; i < cfg.iterations; i++ {
+		size := 1 + i*100/max(cfg.iterations, 1)
+		input := cfg.generate(r, typ, size)
+		if holds(input) {
+			continue
+		}
+
+		shrunk, steps := shrinkValue(input, holds, cfg.gens)
+		return fail(t, label,
+			"Expected %s to hold for all inputs, but it failed after %d tests (seed %d, shrunk %d times) for %#v.",
+			label, i+1, cfg.seed, steps, gotValue(shrunk.Interface()),
+		)
+	}
+	return pass(t)
+}
+
+// generate returns a random value of type typ whose size is bounded by size.
+func (cfg propertyConfig) generate(r *rand.Rand, typ reflect.Type, size int) reflect.Value {
+	if gen, ok := cfg.gens[typ]; ok {
+		v := reflect.New(typ).Elem()
+		if g := gen(r, size); g.IsValid() {
+			v.Set(g)
+		}
+		return v
+	}
+
+	v := reflect.New(typ).Elem()
+	switch typ.Kind() {
+	case reflect.Bool:
+		v.SetBool(r.IntN(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := r.Int64N(int64(2*size+1)) - int64(size)
+		if v.OverflowInt(n) {
+			n = 0
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n := r.Uint64N(uint64(size + 1))
+		if v.OverflowUint(n) {
+			n = 0
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat((r.Float64()*2 - 1) * float64(size))
+	case reflect.String:
+		b := make([]rune, r.IntN(size+1))
+		for i := range b {
+			b[i] = rune(' ' + r.IntN('~'-' '+1))
+		}
+		v.SetString(string(b))
+	case reflect.Slice:
+		n := r.IntN(size + 1)
+		v.Set(reflect.MakeSlice(typ, n, n))
+		for i := 0; i < n; i++ {
+			v.Index(i).Set(cfg.generate(r, typ.Elem(), size))
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			v.Index(i).Set(cfg.generate(r, typ.Elem(), size))
+		}
+	case reflect.Map:
+		v.Set(reflect.MakeMap(typ))
+		for n := r.IntN(size + 1); n > 0; n-- {
+			v.SetMapIndex(cfg.generate(r, typ.Key(), size), cfg.generate(r, typ.Elem(), size))
+		}
+	case reflect.Pointer:
+		// Generate nil occasionally so that code under test sees it.
+		if r.IntN(10) > 0 {
+			v.Set(reflect.New(typ.Elem()))
+			v.Elem().Set(cfg.generate(r, typ.Elem(), size))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if typ.Field(i).IsExported() {
+				v.Field(i).Set(cfg.generate(r, typ.Field(i).Type, size))
+			}
+		}
+	}
+	return v
+}
+
+// shrinkValue repeatedly replaces v with a simpler value for which holds still
+// returns false, stopping when no simpler failing value can be found. It
+// returns the simplest value found and the number of times it was simplified.
+func shrinkValue(v reflect.Value, holds func(reflect.Value) bool, gens map[reflect.Type]func(*rand.Rand, int) reflect.Value) (reflect.Value, int) {
+	steps := 0
+	for steps < maxShrinkSteps {
+		shrunk := false
+		for _, candidate := range shrinkCandidates(v, gens) {
+			if !holds(candidate) {
+				v, shrunk = candidate, true
+				steps++
+				break
+			}
+		}
+		if !shrunk {
+			break
+		}
+	}
+	return v, steps
+}
+
+// shrinkCandidates returns values that are simpler than v, with the simplest
+// candidates first.
+func shrinkCandidates(v reflect.Value, gens map[reflect.Type]func(*rand.Rand, int) reflect.Value) []reflect.Value {
+	typ := v.Type()
+	if _, ok := gens[typ]; ok {
+		return nil
+	}
+
+	var candidates []reflect.Value
+	add := func(set func(c reflect.Value)) {
+		c := reflect.New(typ).Elem()
+		c.Set(v)
+		set(c)
+		candidates = append(candidates, c)
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			add(func(c reflect.Value) { c.SetBool(false) })
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := v.Int()
+		for d := n; d != 0; d /= 2 {
+			add(func(c reflect.Value) { c.SetInt(n - d) })
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n := v.Uint()
+		for d := n; d != 0; d /= 2 {
+			add(func(c reflect.Value) { c.SetUint(n - d) })
+		}
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f != 0 {
+			add(func(c reflect.Value) { c.SetFloat(0) })
+		}
+		if trunc := float64(int64(f)); trunc != f {
+			add(func(c reflect.Value) { c.SetFloat(trunc) })
+		}
+	case reflect.String:
+		runes := []rune(v.String())
+		for _, rs := range shrinkSlice(runes) {
+			add(func(c reflect.Value) { c.SetString(string(rs)) })
+		}
+	case reflect.Slice:
+		n := v.Len()
+		// Try removing chunks of elements, largest first.
+		for chunk := n; chunk > 0; chunk /= 2 {
+			for start := 0; start+chunk <= n; start += chunk {
+				add(func(c reflect.Value) {
+					c.Set(reflect.AppendSlice(
+						reflect.AppendSlice(reflect.MakeSlice(typ, 0, n-chunk), v.Slice(0, start)),
+						v.Slice(start+chunk, n),
+					))
+				})
+			}
+		}
+		for i := 0; i < n; i++ {
+			for _, elem := range shrinkCandidates(v.Index(i), gens) {
+				add(func(c reflect.Value) {
+					c.Set(reflect.AppendSlice(reflect.MakeSlice(typ, 0, n), v))
+					c.Index(i).Set(elem)
+				})
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			for _, elem := range shrinkCandidates(v.Index(i), gens) {
+				add(func(c reflect.Value) { c.Index(i).Set(elem) })
+			}
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			add(func(c reflect.Value) {
+				m := reflect.MakeMap(typ)
+				iter := v.MapRange()
+				for iter.Next() {
+					if !iter.Key().Equal(key) {
+						m.SetMapIndex(iter.Key(), iter.Value())
+					}
+				}
+				c.Set(m)
+			})
+		}
+	case reflect.Pointer:
+		if !v.IsNil() {
+			for _, elem := range shrinkCandidates(v.Elem(), gens) {
+				add(func(c reflect.Value) {
+					p := reflect.New(typ.Elem())
+					p.Elem().Set(elem)
+					c.Set(p)
+				})
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !typ.Field(i).IsExported() {
+				continue
+			}
+			for _, field := range shrinkCandidates(v.Field(i), gens) {
+				add(func(c reflect.Value) { c.Field(i).Set(field) })
+			}
+		}
+	}
+	return candidates
+}
+
+// shrinkSlice returns copies of s with chunks of elements removed, largest
+// chunks first.
+func shrinkSlice[E any](s []E) [][]E {
+	var shrunk [][]E
+	for chunk := len(s); chunk > 0; chunk /= 2 {
+		for start := 0; start+chunk <= len(s); start += chunk {
+			shrunk = append(shrunk, append(s[:start:start], s[start+chunk:]...))
+		}
+	}
+	return shrunk
+}
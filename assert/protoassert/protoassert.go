@@ -0,0 +1,229 @@
+// Package protoassert contains assertions for testing code that works with
+// protocol buffer messages. These live in a separate package from assert so
+// that projects that don't use protocol buffers don't need to depend on them.
+package protoassert
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/haleyrc/lib/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Equal validates that two protocol buffer messages are equal according to
+// [proto.Equal]. Comparing messages with assert.DeepEqual is unreliable since
+// generated message types contain internal state, so this assertion should be
+// used instead.
+//
+// On failure, each field that differs is listed along with its path and the
+// wanted and actual values.
+func Equal(t assert.T, label string, want, got proto.Message) assert.Result {
+	t.Helper()
+	if proto.Equal(want, got) {
+		return assert.Pass(t)
+	}
+
+	var diffs []string
+	switch {
+	case want == nil || got == nil:
+		diffs = append(diffs, fmt.Sprintf("want %v, got %v", want, got))
+	case want.ProtoReflect().Descriptor().FullName() != got.ProtoReflect().Descriptor().FullName():
+		diffs = append(diffs, fmt.Sprintf(
+			"want %s, got %s",
+			want.ProtoReflect().Descriptor().FullName(),
+			got.ProtoReflect().Descriptor().FullName(),
+		))
+	default:
+		diffs = diffMessages("", want.ProtoReflect(), got.ProtoReflect())
+	}
+
+	return assert.Fail(t, label,
+		"Expected %s to be equal, but they weren't:\n\t%s",
+		label, strings.Join(diffs, "\n\t"),
+	)
+}
+
+func diffMessages(path string, want, got protoreflect.Message) []string {
+	var diffs []string
+	if want.IsValid() != got.IsValid() {
+		return []string{fmt.Sprintf("%s: want %s, got %s", displayPath(path), presence(want.IsValid()), presence(got.IsValid()))}
+	}
+
+	fields := want.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		fieldPath := joinPath(path, string(fd.Name()))
+
+		if fd.HasPresence() && want.Has(fd) != got.Has(fd) {
+			diffs = append(diffs, fmt.Sprintf("%s: want %s, got %s",
+				fieldPath, formatField(fd, want), formatField(fd, got)))
+			continue
+		}
+
+		switch {
+		case fd.IsList():
+			diffs = append(diffs, diffLists(fieldPath, fd, want.Get(fd).List(), got.Get(fd).List())...)
+		case fd.IsMap():
+			diffs = append(diffs, diffMaps(fieldPath, fd, want.Get(fd).Map(), got.Get(fd).Map())...)
+		default:
+			diffs = append(diffs, diffValues(fieldPath, fd, want.Get(fd), got.Get(fd))...)
+		}
+	}
+
+	if !bytes.Equal(want.GetUnknown(), got.GetUnknown()) {
+		diffs = append(diffs, fmt.Sprintf("%s: unknown fields differ", displayPath(path)))
+	}
+	return diffs
+}
+
+func diffLists(path string, fd protoreflect.FieldDescriptor, want, got protoreflect.List) []string {
+	if want.Len() != got.Len() {
+		return []string{fmt.Sprintf("%s: want %d elements, got %d", path, want.Len(), got.Len())}
+	}
+	var diffs []string
+	for i := 0; i < want.Len(); i++ {
+		diffs = append(diffs, diffValues(fmt.Sprintf("%s[%d]", path, i), fd, want.Get(i), got.Get(i))...)
+	}
+	return diffs
+}
+
+func diffMaps(path string, fd protoreflect.FieldDescriptor, want, got protoreflect.Map) []string {
+	keys := make(map[string]protoreflect.MapKey)
+	want.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys[fmt.Sprint(k.Interface())] = k
+		return true
+	})
+	got.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys[fmt.Sprint(k.Interface())] = k
+		return true
+	})
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var diffs []string
+	for _, name := range names {
+		k := keys[name]
+		entryPath := fmt.Sprintf("%s[%q]", path, name)
+		switch {
+		case !want.Has(k):
+			diffs = append(diffs, fmt.Sprintf("%s: want <unset>, got %s", entryPath, formatValue(fd.MapValue(), got.Get(k))))
+		case !got.Has(k):
+			diffs = append(diffs, fmt.Sprintf("%s: want %s, got <unset>", entryPath, formatValue(fd.MapValue(), want.Get(k))))
+		default:
+			diffs = append(diffs, diffValues(entryPath, fd.MapValue(), want.Get(k), got.Get(k))...)
+		}
+	}
+	return diffs
+}
+
+func diffValues(path string, fd protoreflect.FieldDescriptor, want, got protoreflect.Value) []string {
+	if fd.Message() != nil {
+		return diffMessages(path, want.Message(), got.Message())
+	}
+	if !scalarEqual(want.Interface(), got.Interface()) {
+		return []string{fmt.Sprintf("%s: want %s, got %s", path, formatValue(fd, want), formatValue(fd, got))}
+	}
+	return nil
+}
+
+// scalarEqual compares scalar field values. Floating point values are compared
+// bitwise so that NaN values match, consistent with proto.Equal.
+func scalarEqual(want, got any) bool {
+	switch w := want.(type) {
+	case []byte:
+		return bytes.Equal(w, got.([]byte))
+	case float32:
+		g := got.(float32)
+		return w == g || (w != w && g != g)
+	case float64:
+		g := got.(float64)
+		return w == g || (w != w && g != g)
+	}
+	return reflect.DeepEqual(want, got)
+}
+
+func formatField(fd protoreflect.FieldDescriptor, m protoreflect.Message) string {
+	if !m.Has(fd) {
+		return "<unset>"
+	}
+	return formatFieldValue(fd, m.Get(fd))
+}
+
+// formatFieldValue formats the value of the field fd, which may be a list.
+func formatFieldValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	if !fd.IsList() {
+		return formatValue(fd, v)
+	}
+	list := v.List()
+	elems := make([]string, list.Len())
+	for i := range elems {
+		elems[i] = formatValue(fd, list.Get(i))
+	}
+	return "[" + strings.Join(elems, ", ") + "]"
+}
+
+// formatValue formats a single value of the field fd. For list fields, v must
+// be an element of the list.
+func formatValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch {
+	case fd.Message() != nil:
+		return formatMessage(v.Message())
+	case fd.Kind() == protoreflect.StringKind:
+		return fmt.Sprintf("%q", v.String())
+	case fd.Kind() == protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return fmt.Sprint(v.Enum())
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// formatMessage returns a compact representation of m listing its populated
+// fields in field number order. This is used instead of the String method of
+// generated messages, whose output is deliberately unstable.
+func formatMessage(m protoreflect.Message) string {
+	var fields []string
+	fds := m.Descriptor().Fields()
+	for i := 0; i < fds.Len(); i++ {
+		fd := fds.Get(i)
+		if !m.Has(fd) {
+			continue
+		}
+		if fd.IsMap() {
+			fields = append(fields, fmt.Sprintf("%s: map[%d entries]", fd.Name(), m.Get(fd).Map().Len()))
+			continue
+		}
+		fields = append(fields, fmt.Sprintf("%s: %s", fd.Name(), formatFieldValue(fd, m.Get(fd))))
+	}
+	return "{" + strings.Join(fields, ", ") + "}"
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "(message)"
+	}
+	return path
+}
+
+func presence(valid bool) string {
+	if valid {
+		return "<set>"
+	}
+	return "<unset>"
+}
@@ -0,0 +1,45 @@
+package protoassert_test
+
+import (
+	"github.com/haleyrc/lib/assert/protoassert"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func ExampleEqual() {
+	want, _ := structpb.NewStruct(map[string]any{
+		"name":  "Bender",
+		"parts": []any{"antenna", "arm"},
+	})
+	got, _ := structpb.NewStruct(map[string]any{
+		"name":  "Flexo",
+		"parts": []any{"antenna", "arm"},
+		"beard": true,
+	})
+
+	protoassert.Equal(t, "robot", want, want)
+	protoassert.Equal(t, "robot", want, got)
+
+	// Output: Expected robot to be equal, but they weren't:
+	// 	fields["beard"]: want <unset>, got {bool_value: true}
+	// 	fields["name"].string_value: want "Bender", got "Flexo"
+}
+
+func ExampleEqual_lists() {
+	want := structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{
+		structpb.NewStringValue("antenna"),
+	}})
+	got := structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{
+		structpb.NewStringValue("antenna"),
+		structpb.NewStringValue("arm"),
+	}})
+	unset := structpb.NewNullValue()
+
+	protoassert.Equal(t, "parts", want, got)
+	protoassert.Equal(t, "parts", unset, got)
+
+	// Output: Expected parts to be equal, but they weren't:
+	// 	list_value.values: want 1 elements, got 2
+	// Expected parts to be equal, but they weren't:
+	// 	null_value: want NULL_VALUE, got <unset>
+	// 	list_value: want <unset>, got {values: [{string_value: "antenna"}, {string_value: "arm"}]}
+}
@@ -0,0 +1,573 @@
+// Package require contains functions for making test assertions that stop the
+// test immediately on failure.
+//
+// Every function in this package mirrors the function of the same name in
+// package assert and shares its implementation. The only difference is that a
+// failed assertion calls FailNow on the provided T rather than allowing the
+// test to continue, which removes the need to chain Fatal onto every assertion
+// in setup-heavy tests.
+package require
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/haleyrc/lib/assert"
+)
+
+// BigEqual validates that two arbitrary-precision numbers are numerically
+// equal.
+func BigEqual[N assert.BigNumber](t assert.T, label string, want, got N) {
+	t.Helper()
+	assert.BigEqual(t, label, want, got).Fatal()
+}
+
+// BodyContains validates that the body of the provided response contains the
+// desired string. The body can still be read after the assertion.
+func BodyContains[R assert.Response](t assert.T, resp R, want string) {
+	t.Helper()
+	assert.BodyContains(t, resp, want).Fatal()
+}
+
+// Closed validates that ch is closed within the timeout.
+func Closed[V any](t assert.T, label string, ch <-chan V, timeout time.Duration) {
+	t.Helper()
+	assert.Closed(t, label, ch, timeout).Fatal()
+}
+
+// BodyGolden validates that the body of the provided response matches the
+// contents of the golden file at path.
+func BodyGolden[R assert.Response](t assert.T, resp R, path string) {
+	t.Helper()
+	assert.BodyGolden(t, resp, path).Fatal()
+}
+
+// CompletesWithin validates that f returns within timeout.
+func CompletesWithin(t assert.T, label string, timeout time.Duration, f func()) {
+	t.Helper()
+	assert.CompletesWithin(t, label, timeout, f).Fatal()
+}
+
+// Condition validates that the provided predicate returns true.
+func Condition(t assert.T, label string, f func() bool) {
+	t.Helper()
+	assert.Condition(t, label, f).Fatal()
+}
+
+// Conditionf is like Condition, but the label is built from a format string
+// and arguments.
+func Conditionf(t assert.T, f func() bool, format string, args ...any) {
+	t.Helper()
+	assert.Conditionf(t, f, format, args...).Fatal()
+}
+
+// ContentType validates that the value of the `Content-Type` header of the
+// provided response matches the desired media type.
+func ContentType[R assert.Response](t assert.T, resp R, want string) {
+	t.Helper()
+	assert.ContentType(t, resp, want).Fatal()
+}
+
+// ContextAlive validates that ctx has not been canceled and that its deadline,
+// if any, has not passed.
+func ContextAlive(t assert.T, ctx context.Context) {
+	t.Helper()
+	assert.ContextAlive(t, ctx).Fatal()
+}
+
+// ContextCanceled validates that ctx is done because it was canceled.
+func ContextCanceled(t assert.T, ctx context.Context) {
+	t.Helper()
+	assert.ContextCanceled(t, ctx).Fatal()
+}
+
+// ContextDeadlineExceeded validates that ctx is done because its deadline
+// passed.
+func ContextDeadlineExceeded(t assert.T, ctx context.Context) {
+	t.Helper()
+	assert.ContextDeadlineExceeded(t, ctx).Fatal()
+}
+
+// Cookie validates that the provided response sets a cookie with the given name
+// and returns the parsed cookie.
+func Cookie[R assert.Response](t assert.T, resp R, name string) *http.Cookie {
+	t.Helper()
+	cookie, result := assert.Cookie(t, resp, name)
+	result.Fatal()
+	return cookie
+}
+
+// CSVEqual validates that two strings contain the same CSV records.
+func CSVEqual(t assert.T, label, want, got string, opts ...assert.CSVOption) {
+	t.Helper()
+	assert.CSVEqual(t, label, want, got, opts...).Fatal()
+}
+
+// DeepEqual validates that two values are "deeply equal" according to the same
+// rules as [reflect.DeepEqual].
+func DeepEqual(t assert.T, label string, want, got any) {
+	t.Helper()
+	assert.DeepEqual(t, label, want, got).Fatal()
+}
+
+// DeepEqualIgnoring validates that two values are "deeply equal" while
+// skipping the struct fields named by ignore.
+func DeepEqualIgnoring(t assert.T, label string, want, got any, ignore ...string) {
+	t.Helper()
+	assert.DeepEqualIgnoring(t, label, want, got, ignore...).Fatal()
+}
+
+// DeepEqualWith validates that two values are "deeply equal" using the
+// provided Comparers for values of matching types.
+func DeepEqualWith(t assert.T, label string, want, got any, opts ...assert.Comparer) {
+	t.Helper()
+	assert.DeepEqualWith(t, label, want, got, opts...).Fatal()
+}
+
+// DirEqual validates that two directory trees contain the same files with the
+// same contents.
+func DirEqual(t assert.T, want, got fs.FS) {
+	t.Helper()
+	assert.DirEqual(t, want, got).Fatal()
+}
+
+// DurationWithin validates that got is within tolerance of want, inclusive.
+func DurationWithin(t assert.T, label string, want, got, tolerance time.Duration) {
+	t.Helper()
+	assert.DurationWithin(t, label, want, got, tolerance).Fatal()
+}
+
+// Equal validates that two values are the same.
+func Equal[C comparable](t assert.T, label string, want, got C) {
+	t.Helper()
+	assert.Equal(t, label, want, got).Fatal()
+}
+
+// EqualExportedFields validates that two values are "deeply equal" while
+// ignoring unexported struct fields.
+func EqualExportedFields(t assert.T, label string, want, got any) {
+	t.Helper()
+	assert.EqualExportedFields(t, label, want, got).Fatal()
+}
+
+// EqualFold validates that two strings are equal under simple Unicode
+// case-folding.
+func EqualFold(t assert.T, label, want, got string) {
+	t.Helper()
+	assert.EqualFold(t, label, want, got).Fatal()
+}
+
+// EqualFunc validates that two values are the same according to eq.
+func EqualFunc[V any](t assert.T, label string, want, got V, eq func(want, got V) bool) {
+	t.Helper()
+	assert.EqualFunc(t, label, want, got, eq).Fatal()
+}
+
+// Error validates that the provided error is not nil and contains the desired
+// string.
+func Error(t assert.T, err error, want string) {
+	t.Helper()
+	assert.Error(t, err, want).Fatal()
+}
+
+// False validates that the provided value is false.
+func False(t assert.T, label string, got bool) {
+	t.Helper()
+	assert.False(t, label, got).Fatal()
+}
+
+// ErrorCode validates that err or any error it wraps has a Code method
+// returning want.
+func ErrorCode(t assert.T, err error, want string) {
+	t.Helper()
+	assert.ErrorCode(t, err, want).Fatal()
+}
+
+// ErrorCount validates that err is made up of exactly want individual errors.
+func ErrorCount(t assert.T, err error, want int) {
+	t.Helper()
+	assert.ErrorCount(t, err, want).Fatal()
+}
+
+// ErrorsContain validates that for each of the desired strings, at least one
+// of the individual errors making up err contains that string.
+func ErrorsContain(t assert.T, err error, wants ...string) {
+	t.Helper()
+	assert.ErrorsContain(t, err, wants...).Fatal()
+}
+
+// ExitsWith runs cmd and validates that it exits with the desired exit code.
+func ExitsWith(t assert.T, want int, cmd *exec.Cmd) {
+	t.Helper()
+	assert.ExitsWith(t, want, cmd).Fatal()
+}
+
+// FieldsMatch validates that the listed fields of two structs are "deeply
+// equal".
+func FieldsMatch(t assert.T, label string, want, got any, fields ...string) {
+	t.Helper()
+	assert.FieldsMatch(t, label, want, got, fields...).Fatal()
+}
+
+// FileContains validates that the named file exists in fsys and that its
+// contents contain the desired string.
+func FileContains(t assert.T, fsys fs.FS, name, want string) {
+	t.Helper()
+	assert.FileContains(t, fsys, name, want).Fatal()
+}
+
+// FileContent validates that the named file exists in fsys and that its
+// contents are exactly the desired string.
+func FileContent(t assert.T, fsys fs.FS, name, want string) {
+	t.Helper()
+	assert.FileContent(t, fsys, name, want).Fatal()
+}
+
+// FileExists validates that the named file exists in fsys.
+func FileExists(t assert.T, fsys fs.FS, name string) {
+	t.Helper()
+	assert.FileExists(t, fsys, name).Fatal()
+}
+
+// FileMode validates that the permission bits of the named file in fsys match
+// the desired permissions.
+func FileMode(t assert.T, fsys fs.FS, name string, want fs.FileMode) {
+	t.Helper()
+	assert.FileMode(t, fsys, name, want).Fatal()
+}
+
+// GraphQLData validates that the body of the provided response is a GraphQL
+// response containing data and decodes that data into dest.
+func GraphQLData[R assert.Response](t assert.T, resp R, dest any) {
+	t.Helper()
+	assert.GraphQLData(t, resp, dest).Fatal()
+}
+
+// Header validates that the value of the named header of the provided response
+// matches the desired value.
+func Header[R assert.Response](t assert.T, resp R, key, want string) {
+	t.Helper()
+	assert.Header(t, resp, key, want).Fatal()
+}
+
+// HeaderAbsent validates that the named header is not present in the provided
+// response.
+func HeaderAbsent[R assert.Response](t assert.T, resp R, key string) {
+	t.Helper()
+	assert.HeaderAbsent(t, resp, key).Fatal()
+}
+
+// HeaderPresent validates that the named header is present in the provided
+// response.
+func HeaderPresent[R assert.Response](t assert.T, resp R, key string) {
+	t.Helper()
+	assert.HeaderPresent(t, resp, key).Fatal()
+}
+
+// Implements validates that the dynamic type of value satisfies the interface
+// I.
+func Implements[I any](t assert.T, label string, value any) {
+	t.Helper()
+	assert.Implements[I](t, label, value).Fatal()
+}
+
+// IsType validates that the dynamic type of got is E and returns got as an E.
+func IsType[E any](t assert.T, label string, got any) E {
+	t.Helper()
+	e, result := assert.IsType[E](t, label, got)
+	result.Fatal()
+	return e
+}
+
+// JSONEqual validates that two strings contain equivalent JSON documents.
+func JSONEqual(t assert.T, label, want, got string, opts ...assert.JSONOption) {
+	t.Helper()
+	assert.JSONEqual(t, label, want, got, opts...).Fatal()
+}
+
+// LoggedError validates that at least one record was logged at or above the
+// error level with a message containing the desired string.
+func LoggedError(t assert.T, logs *assert.Logs, want string) {
+	t.Helper()
+	assert.LoggedError(t, logs, want).Fatal()
+}
+
+// LoggedWithAttr validates that at least one record was logged with an
+// attribute with the given key and value.
+func LoggedWithAttr(t assert.T, logs *assert.Logs, key string, value any) {
+	t.Helper()
+	assert.LoggedWithAttr(t, logs, key, value).Fatal()
+}
+
+// MapContains validates that m contains key and that its value is value.
+func MapContains[M ~map[K]V, K, V comparable](t assert.T, label string, m M, key K, value V) {
+	t.Helper()
+	assert.MapContains(t, label, m, key, value).Fatal()
+}
+
+// MapContainsKey validates that m contains key.
+func MapContainsKey[M ~map[K]V, K comparable, V any](t assert.T, label string, m M, key K) {
+	t.Helper()
+	assert.MapContainsKey(t, label, m, key).Fatal()
+}
+
+// MapContainsValue validates that at least one key in m maps to value.
+func MapContainsValue[M ~map[K]V, K, V comparable](t assert.T, label string, m M, value V) {
+	t.Helper()
+	assert.MapContainsValue(t, label, m, value).Fatal()
+}
+
+// MatchesJSONSchema validates that doc is a JSON document that conforms to the
+// provided JSON Schema.
+func MatchesJSONSchema(t assert.T, schema, doc []byte) {
+	t.Helper()
+	assert.MatchesJSONSchema(t, schema, doc).Fatal()
+}
+
+// MaxAllocs validates that f allocates no more than n times per call on
+// average.
+func MaxAllocs(t assert.T, label string, n int, f func()) {
+	t.Helper()
+	assert.MaxAllocs(t, label, n, f).Fatal()
+}
+
+// MultipartField validates that the body of the provided request is a
+// multipart form containing a non-file field with the given name and value.
+func MultipartField(t assert.T, r *http.Request, name, want string) {
+	t.Helper()
+	assert.MultipartField(t, r, name, want).Fatal()
+}
+
+// MultipartFile validates that the body of the provided request is a
+// multipart form containing a file part with the given field name whose
+// filename, content type, and content all match want.
+func MultipartFile(t assert.T, r *http.Request, name string, want assert.FilePart) {
+	t.Helper()
+	assert.MultipartFile(t, r, name, want).Fatal()
+}
+
+// Negative validates that got is less than zero.
+func Negative[N assert.Number](t assert.T, label string, got N) {
+	t.Helper()
+	assert.Negative(t, label, got).Fatal()
+}
+
+// NoGoroutineLeak takes a snapshot of the running goroutines and returns a
+// function that validates that no new goroutines are still running when it is
+// called.
+func NoGoroutineLeak(t assert.T) func() {
+	t.Helper()
+	return assert.NoGoroutineLeak(fatalT{t})
+}
+
+// NoGraphQLErrors validates that the body of the provided response is a
+// GraphQL response without any errors.
+func NoGraphQLErrors[R assert.Response](t assert.T, resp R) {
+	t.Helper()
+	assert.NoGraphQLErrors(t, resp).Fatal()
+}
+
+// NoErrorsLogged validates that no records were logged at or above the error
+// level, including those logged by Fatal.
+func NoErrorsLogged(t assert.T, logs *assert.Logs) {
+	t.Helper()
+	assert.NoErrorsLogged(t, logs).Fatal()
+}
+
+// NonNegative validates that got is greater than or equal to zero.
+func NonNegative[N assert.Number](t assert.T, label string, got N) {
+	t.Helper()
+	assert.NonNegative(t, label, got).Fatal()
+}
+
+// NotBlank validates that the provided string is not the blank string. Leading
+// and trailing spaces are removed from got before validation.
+func NotBlank(t assert.T, label string, got string) {
+	t.Helper()
+	assert.NotBlank(t, label, got).Fatal()
+}
+
+// NotDeepEqual validates that two values are not "deeply equal" according to
+// the rules of [reflect.DeepEqual].
+func NotDeepEqual(t assert.T, label string, unwanted, got any) {
+	t.Helper()
+	assert.NotDeepEqual(t, label, unwanted, got).Fatal()
+}
+
+// NotEqual validates that two values are different.
+func NotEqual[C comparable](t assert.T, label string, unwanted, got C) {
+	t.Helper()
+	assert.NotEqual(t, label, unwanted, got).Fatal()
+}
+
+// NumericEqual validates that two strings represent the same number.
+func NumericEqual(t assert.T, label, want, got string) {
+	t.Helper()
+	assert.NumericEqual(t, label, want, got).Fatal()
+}
+
+// OK validates that the provided err is nil.
+func OK(t assert.T, err error) {
+	t.Helper()
+	assert.OK(t, err).Fatal()
+}
+
+// Positive validates that got is greater than zero.
+func Positive[N assert.Number](t assert.T, label string, got N) {
+	t.Helper()
+	assert.Positive(t, label, got).Fatal()
+}
+
+// Property validates that pred holds for randomly generated inputs of type A.
+func Property[A any](t assert.T, label string, pred func(A) bool, opts ...assert.PropertyOption) {
+	t.Helper()
+	assert.Property(t, label, pred, opts...).Fatal()
+}
+
+// RedirectsTo validates that the provided response is a redirect to the
+// desired URL.
+func RedirectsTo[R assert.Response](t assert.T, resp R, want string) {
+	t.Helper()
+	assert.RedirectsTo(t, resp, want).Fatal()
+}
+
+// RedirectsToPrefix validates that the provided response is a redirect to a URL
+// starting with the desired prefix.
+func RedirectsToPrefix[R assert.Response](t assert.T, resp R, prefix string) {
+	t.Helper()
+	assert.RedirectsToPrefix(t, resp, prefix).Fatal()
+}
+
+// Receives validates that the next value received from ch within the timeout
+// is equal to want.
+func Receives[V comparable](t assert.T, label string, ch <-chan V, want V, timeout time.Duration) {
+	t.Helper()
+	assert.Receives(t, label, ch, want, timeout).Fatal()
+}
+
+// ReceivesJSON validates that the next message received from conn within the
+// timeout is a JSON document that decodes to the value want points to.
+func ReceivesJSON(t assert.T, conn assert.WebSocketConn, want any, timeout time.Duration) {
+	t.Helper()
+	assert.ReceivesJSON(t, conn, want, timeout).Fatal()
+}
+
+// ReceivesMessage validates that the next message received from conn within
+// the timeout is exactly want.
+func ReceivesMessage(t assert.T, conn assert.WebSocketConn, want string, timeout time.Duration) {
+	t.Helper()
+	assert.ReceivesMessage(t, conn, want, timeout).Fatal()
+}
+
+// ReceivesWithin validates that a value is received from ch within the timeout
+// and returns the value.
+func ReceivesWithin[V any](t assert.T, label string, ch <-chan V, timeout time.Duration) V {
+	t.Helper()
+	got, result := assert.ReceivesWithin(t, label, ch, timeout)
+	result.Fatal()
+	return got
+}
+
+// RowCount validates that rows contains exactly want rows.
+func RowCount(t assert.T, label string, rows *sql.Rows, want int) {
+	t.Helper()
+	assert.RowCount(t, label, rows, want).Fatal()
+}
+
+// RowsEqual validates that rows contains exactly the rows in want, in order.
+func RowsEqual(t assert.T, label string, want [][]any, rows *sql.Rows) {
+	t.Helper()
+	assert.RowsEqual(t, label, want, rows).Fatal()
+}
+
+// ScanOne validates that rows contains exactly one row and scans it into dest.
+func ScanOne(t assert.T, rows *sql.Rows, dest ...any) {
+	t.Helper()
+	assert.ScanOne(t, rows, dest...).Fatal()
+}
+
+// ShouldPanic validates that calling f results in a panic.
+func ShouldPanic(t assert.T, f func()) {
+	t.Helper()
+	assert.ShouldPanic(t, f).Fatal()
+}
+
+// SliceEqual validates that two slices are the same.
+func SliceEqual[S ~[]E, E comparable](t assert.T, label string, want, got S) {
+	t.Helper()
+	assert.SliceEqual(t, label, want, got).Fatal()
+}
+
+// SliceInDelta validates that two slices of floating-point numbers have the
+// same length and that each pair of elements differs by no more than delta.
+func SliceInDelta[S ~[]F, F assert.Float](t assert.T, label string, want, got S, delta F) {
+	t.Helper()
+	assert.SliceInDelta(t, label, want, got, delta).Fatal()
+}
+
+// StatusCode validates that the status code of the provided response matches
+// the desired value.
+func StatusCode[R assert.Response](t assert.T, want int, resp R) {
+	t.Helper()
+	assert.StatusCode(t, want, resp).Fatal()
+}
+
+// TimeEqual validates that two times represent the same instant after
+// normalization and truncation.
+func TimeEqual(t assert.T, label string, want, got time.Time, truncateTo time.Duration) {
+	t.Helper()
+	assert.TimeEqual(t, label, want, got, truncateTo).Fatal()
+}
+
+// True validates that the provided value is true.
+func True(t assert.T, label string, got bool) {
+	t.Helper()
+	assert.True(t, label, got).Fatal()
+}
+
+// ValidEmail validates that s is a bare email address.
+func ValidEmail(t assert.T, label, s string) {
+	t.Helper()
+	assert.ValidEmail(t, label, s).Fatal()
+}
+
+// ValidURL validates that s is an absolute URL with both a scheme and a host.
+func ValidURL(t assert.T, label, s string) {
+	t.Helper()
+	assert.ValidURL(t, label, s).Fatal()
+}
+
+// ValidUUID validates that s is a UUID in its canonical textual form and,
+// optionally, that it has the provided version.
+func ValidUUID(t assert.T, label, s string, version ...int) {
+	t.Helper()
+	assert.ValidUUID(t, label, s, version...).Fatal()
+}
+
+// XMLEqual validates that two strings contain equivalent XML documents.
+func XMLEqual(t assert.T, label, want, got string) {
+	t.Helper()
+	assert.XMLEqual(t, label, want, got).Fatal()
+}
+
+// YAMLEqual validates that two strings contain equivalent YAML documents.
+func YAMLEqual(t assert.T, label, want, got string) {
+	t.Helper()
+	assert.YAMLEqual(t, label, want, got).Fatal()
+}
+
+// fatalT wraps a T so that every reported failure stops the test. This is only
+// needed for assertions that don't return a Result.
+type fatalT struct {
+	assert.T
+}
+
+func (t fatalT) Errorf(format string, args ...any) {
+	t.T.Helper()
+	t.T.Errorf(format, args...)
+	t.T.FailNow()
+}
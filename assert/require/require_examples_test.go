@@ -0,0 +1,23 @@
+package require_test
+
+import (
+	"errors"
+
+	"github.com/haleyrc/lib/assert/require"
+)
+
+func ExampleEqual() {
+	require.Equal(t, "int", 42, 42)
+	require.Equal(t, "int", 42, 13)
+
+	// Output: Expected int to be 42, but got 13.
+	// FailNow called.
+}
+
+func ExampleOK() {
+	require.OK(t, nil)
+	require.OK(t, errors.New("oops"))
+
+	// Output: Unexpected error: oops.
+	// FailNow called.
+}
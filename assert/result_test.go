@@ -0,0 +1,15 @@
+package assert_test
+
+import (
+	"testing"
+
+	"github.com/haleyrc/lib/assert"
+)
+
+func TestAll_noResults(t *testing.T) {
+	r := assert.All()
+	if !r.OK() {
+		t.Error("Expected All with no results to be OK, but it wasn't.")
+	}
+	r.Msgf("unused").Fatal()
+}
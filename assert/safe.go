@@ -0,0 +1,148 @@
+package assert
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// SafeT is a T that can be shared by multiple goroutines. Failures and log
+// messages are buffered rather than being sent to the underlying T straight
+// away, and are only reported when the test goroutine checks in by calling
+// Check or Done.
+//
+// To create a new SafeT, call Safe with the T for the current test and pass
+// the SafeT to assertions made from other goroutines:
+//
+//	s := assert.Safe(t)
+//	var wg sync.WaitGroup
+//	for _, job := range jobs {
+//		wg.Add(1)
+//		go func() {
+//			defer wg.Done()
+//			assert.OK(s, worker.Run(job))
+//		}()
+//	}
+//	wg.Wait()
+//	s.Check()
+//
+// If the underlying T supports cleanup functions (as [testing.T] does), Done
+// is also called automatically when the test finishes. Any failure reported
+// after Done causes a panic with a message identifying the failure, since it
+// can no longer be attributed to the test. Log messages received after Done
+// are discarded.
+type SafeT struct {
+	t T
+
+	mu      sync.Mutex
+	entries []safeEntry
+	fatal   bool
+	skip    []any
+	done    bool
+}
+
+type safeEntry struct {
+	msg     string
+	failure bool
+}
+
+// Safe creates a new SafeT that reports to t.
+func Safe(t T) *SafeT {
+	s := &SafeT{t: t}
+	if ct, ok := t.(interface{ Cleanup(func()) }); ok {
+		ct.Cleanup(func() { s.Done() })
+	}
+	return s
+}
+
+// Check reports any failures and log messages buffered since the last call to
+// Check to the underlying T, in the order they were received. If any goroutine
+// called FailNow, Check then stops the test by calling FailNow on the
+// underlying T. Otherwise, if any goroutine called Skip, Check skips the test
+// by calling Skip on the underlying T. Check must be called from the test
+// goroutine.
+//
+// The returned Result is only OK if there were no failures to report.
+func (s *SafeT) Check() Result {
+	s.t.Helper()
+
+	s.mu.Lock()
+	entries, fatal, skip := s.entries, s.fatal, s.skip
+	s.entries, s.fatal, s.skip = nil, false, nil
+	s.mu.Unlock()
+
+	var failed bool
+	for _, e := range entries {
+		if e.failure {
+			failed = true
+			s.t.Errorf("%s", e.msg)
+		} else {
+			s.t.Log(e.msg)
+		}
+	}
+	if fatal {
+		s.t.FailNow()
+	}
+	if skip != nil {
+		s.t.Skip(skip...)
+	}
+
+	return Result{t: s.t, failed: failed}
+}
+
+// Done calls Check and then marks the SafeT as finished. Failures reported
+// after calling Done cause a panic.
+func (s *SafeT) Done() Result {
+	s.t.Helper()
+	s.mu.Lock()
+	s.done = true
+	s.mu.Unlock()
+	return s.Check()
+}
+
+// Errorf buffers a failure to be reported by the next call to Check.
+func (s *SafeT) Errorf(format string, args ...any) {
+	s.add(safeEntry{msg: fmt.Sprintf(format, args...), failure: true})
+}
+
+// FailNow marks the test as needing to stop at the next call to Check and
+// then stops the calling goroutine with [runtime.Goexit].
+func (s *SafeT) FailNow() {
+	s.mu.Lock()
+	s.fatal = true
+	s.mu.Unlock()
+	runtime.Goexit()
+}
+
+// Helper does nothing, since the frames of other goroutines aren't visible
+// to the underlying T.
+func (s *SafeT) Helper() {}
+
+// Log buffers a log message to be reported by the next call to Check.
+func (s *SafeT) Log(args ...any) {
+	s.add(safeEntry{msg: strings.TrimSuffix(fmt.Sprintln(args...), "\n")})
+}
+
+// Skip marks the test as needing to be skipped at the next call to Check and
+// then stops the calling goroutine with [runtime.Goexit].
+func (s *SafeT) Skip(args ...any) {
+	s.mu.Lock()
+	if s.skip == nil {
+		s.skip = append([]any{}, args...)
+	}
+	s.mu.Unlock()
+	runtime.Goexit()
+}
+
+func (s *SafeT) add(e safeEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done {
+		if e.failure {
+			panic(fmt.Sprintf("assert: failure reported after the test finished: %s", e.msg))
+		}
+		return
+	}
+	s.entries = append(s.entries, e)
+}
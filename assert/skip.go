@@ -0,0 +1,88 @@
+package assert
+
+import (
+	"net"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+)
+
+// serviceDialTimeout is how long SkipWithoutService waits for a connection.
+const serviceDialTimeout = time.Second
+
+// SkipIfShort skips the test if the tests are being run with the -short flag.
+// This is useful for excluding slow tests from quick local runs, e.g.:
+//
+//	func TestImport(t *testing.T) {
+//		assert.SkipIfShort(t)
+//		...
+//	}
+func SkipIfShort(t T) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("Skipping in short mode.")
+	}
+}
+
+// SkipWithoutEnv skips the test unless every one of the named environment
+// variables is set to a non-empty value. The values are returned in the same
+// order as the names, e.g.:
+//
+//	dsn := assert.SkipWithoutEnv(t, "DATABASE_URL")[0]
+func SkipWithoutEnv(t T, names ...string) []string {
+	t.Helper()
+	values := make([]string, len(names))
+	for i, name := range names {
+		values[i] = os.Getenv(name)
+		if values[i] == "" {
+			t.Skip("Skipping because environment variable " + name + " is not set.")
+			return nil
+		}
+	}
+	return values
+}
+
+// SkipWithoutDocker skips the test unless a Docker daemon can be reached. The
+// daemon is located using the DOCKER_HOST environment variable if it is set,
+// and the default socket at /var/run/docker.sock otherwise.
+func SkipWithoutDocker(t T) {
+	t.Helper()
+	network, address := "unix", "/var/run/docker.sock"
+	if host := os.Getenv("DOCKER_HOST"); host != "" {
+		u, err := url.Parse(host)
+		if err != nil {
+			t.Skip("Skipping because Docker is not available: invalid DOCKER_HOST " + host + ".")
+			return
+		}
+		switch u.Scheme {
+		case "unix":
+			network, address = "unix", u.Path
+		default:
+			network, address = "tcp", u.Host
+		}
+	}
+	if err := dialService(network, address); err != nil {
+		t.Skip("Skipping because Docker is not available: " + err.Error() + ".")
+	}
+}
+
+// SkipWithoutService skips the test unless a connection can be made to
+// address on the named network within a second. The network and address are
+// interpreted as with [net.Dial], e.g.:
+//
+//	assert.SkipWithoutService(t, "tcp", "localhost:6379")
+func SkipWithoutService(t T, network, address string) {
+	t.Helper()
+	if err := dialService(network, address); err != nil {
+		t.Skip("Skipping because " + address + " is not reachable: " + err.Error() + ".")
+	}
+}
+
+func dialService(network, address string) error {
+	conn, err := net.DialTimeout(network, address, serviceDialTimeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
@@ -0,0 +1,118 @@
+package assert
+
+import (
+	"database/sql"
+	"reflect"
+)
+
+// RowCount validates that rows contains exactly want rows. The rows are
+// consumed and closed.
+func RowCount(t T, label string, rows *sql.Rows, want int) Result {
+	t.Helper()
+	defer rows.Close()
+
+	got := 0
+	for rows.Next() {
+		got++
+	}
+	if err := rows.Err(); err != nil {
+		return fail(t, label, "Unexpected error iterating %s: %v.", label, err)
+	}
+	if got != want {
+		return fail(t, label, "Expected %s to have %d rows, but got %d.", label, wantValue(want), gotValue(got))
+	}
+	return pass(t)
+}
+
+// RowsEqual validates that rows contains exactly the rows in want, in order.
+// The rows are consumed and closed.
+//
+// Since database drivers return a limited set of types, values are normalized
+// before comparison: all signed and unsigned integers are compared as int64,
+// all floats as float64, and []byte values as strings. This means that e.g.
+// the following assertion succeeds for a table with INTEGER and TEXT columns:
+//
+//	assert.RowsEqual(t, "users", [][]any{{1, "Bender"}, {2, "Flexo"}}, rows)
+func RowsEqual(t T, label string, want [][]any, rows *sql.Rows) Result {
+	t.Helper()
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return fail(t, label, "Unexpected error reading columns of %s: %v.", label, err)
+	}
+
+	got := [][]any{}
+	for rows.Next() {
+		values := make([]any, len(cols))
+		dest := make([]any, len(cols))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return fail(t, label, "Unexpected error scanning %s: %v.", label, err)
+		}
+		got = append(got, normalizeRow(values))
+	}
+	if err := rows.Err(); err != nil {
+		return fail(t, label, "Unexpected error iterating %s: %v.", label, err)
+	}
+
+	normalizedWant := make([][]any, len(want))
+	for i, row := range want {
+		normalizedWant[i] = normalizeRow(row)
+	}
+
+	if !reflect.DeepEqual(normalizedWant, got) {
+		return fail(t, label, "Expected %s to be %v, but got %v.", label, wantValue(normalizedWant), gotValue(got))
+	}
+	return pass(t)
+}
+
+// ScanOne validates that rows contains exactly one row and scans it into dest.
+// The rows are consumed and closed.
+func ScanOne(t T, rows *sql.Rows, dest ...any) Result {
+	t.Helper()
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return fail(t, "rows", "Unexpected error iterating rows: %v.", err)
+		}
+		return fail(t, "rows", "Expected exactly one row, but got none.")
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return fail(t, "rows", "Unexpected error scanning row: %v.", err)
+	}
+	if rows.Next() {
+		return fail(t, "rows", "Expected exactly one row, but got more.")
+	}
+	if err := rows.Err(); err != nil {
+		return fail(t, "rows", "Unexpected error iterating rows: %v.", err)
+	}
+	return pass(t)
+}
+
+func normalizeRow(row []any) []any {
+	normalized := make([]any, len(row))
+	for i, v := range row {
+		normalized[i] = normalizeSQLValue(v)
+	}
+	return normalized
+}
+
+func normalizeSQLValue(v any) any {
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	}
+	return v
+}
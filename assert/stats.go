@@ -0,0 +1,87 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var stats sync.Map // map[T]*Stats
+
+// Stats counts the assertions made against a single T. To start counting, call
+// TrackStats.
+type Stats struct {
+	mu       sync.Mutex
+	executed int
+	failed   int
+}
+
+// TrackStats starts counting the assertions made against t and returns the
+// Stats that they are counted in. If t supports cleanup functions (as
+// [testing.T] does), a summary is logged when the test finishes, which makes
+// it easy to spot tests that silently assert nothing:
+//
+//	func TestSomeThing(t *testing.T) {
+//		assert.TrackStats(t)
+//		...
+//	}
+//
+// Only assertions made directly against t are counted, so assertions made
+// against a Collector or a subtest's T are not included.
+func TrackStats(t T) *Stats {
+	s := &Stats{}
+	if !reflect.TypeOf(t).Comparable() {
+		return s
+	}
+	if existing, loaded := stats.LoadOrStore(t, s); loaded {
+		return existing.(*Stats)
+	}
+	if ct, ok := t.(interface{ Cleanup(func()) }); ok {
+		ct.Cleanup(func() {
+			stats.Delete(t)
+			t.Log(s.String())
+		})
+	}
+	return s
+}
+
+// Executed returns the number of assertions that have been made.
+func (s *Stats) Executed() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.executed
+}
+
+// Failed returns the number of assertions that have failed.
+func (s *Stats) Failed() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.failed
+}
+
+// String returns a human-readable summary of the statistics.
+func (s *Stats) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.executed == 0 {
+		return "No assertions were made."
+	}
+	return fmt.Sprintf("Made %d assertions, %d failed.", s.executed, s.failed)
+}
+
+func recordStats(t T, failed bool) {
+	if !reflect.TypeOf(t).Comparable() {
+		return
+	}
+	v, ok := stats.Load(t)
+	if !ok {
+		return
+	}
+	s := v.(*Stats)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.executed++
+	if failed {
+		s.failed++
+	}
+}
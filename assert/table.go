@@ -0,0 +1,47 @@
+package assert
+
+import (
+	"sort"
+	"testing"
+)
+
+// ParallelCase can be implemented by the case type passed to Table to control
+// whether individual cases run in parallel with each other.
+type ParallelCase interface {
+	Parallel() bool
+}
+
+// Table runs fn for each of the provided cases in a subtest named after the
+// case. The function is passed the subtest's T, an Asserter bound to it, and
+// the case itself, which removes the range/t.Run boilerplate from table-driven
+// tests, e.g.:
+//
+//	assert.Table(t, map[string]addCase{
+//		"positive": {a: 1, b: 2, want: 3},
+//		"negative": {a: -1, b: -2, want: -3},
+//	}, func(t *testing.T, a assert.Asserter, tc addCase) {
+//		a.Equal("sum", tc.want, add(tc.a, tc.b))
+//	})
+//
+// Cases are run in order of their names. If the case type implements
+// ParallelCase and Parallel returns true for a case, that case is run in
+// parallel with the other parallel cases.
+func Table[C any](t *testing.T, cases map[string]C, fn func(t *testing.T, a Asserter, tc C)) {
+	t.Helper()
+
+	names := make([]string, 0, len(cases))
+	for name := range cases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		tc := cases[name]
+		t.Run(name, func(t *testing.T) {
+			if pc, ok := any(tc).(ParallelCase); ok && pc.Parallel() {
+				t.Parallel()
+			}
+			fn(t, New(t), tc)
+		})
+	}
+}
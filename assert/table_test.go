@@ -0,0 +1,33 @@
+package assert_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/haleyrc/lib/assert"
+)
+
+type addCase struct {
+	a, b     int
+	want     int
+	parallel bool
+}
+
+func (tc addCase) Parallel() bool { return tc.parallel }
+
+func TestTable(t *testing.T) {
+	var ran atomic.Int32
+
+	t.Run("cases", func(t *testing.T) {
+		assert.Table(t, map[string]addCase{
+			"positive": {a: 1, b: 2, want: 3},
+			"negative": {a: -1, b: -2, want: -3, parallel: true},
+			"mixed":    {a: -1, b: 2, want: 1, parallel: true},
+		}, func(t *testing.T, a assert.Asserter, tc addCase) {
+			ran.Add(1)
+			a.Equal("sum", tc.want, tc.a+tc.b)
+		})
+	})
+
+	assert.Equal(t, "cases run", 3, ran.Load())
+}
@@ -0,0 +1,126 @@
+package assert
+
+import (
+	"reflect"
+	"strings"
+)
+
+// A Subject is a value under test that multiple checks can be chained
+// against. To create a Subject, call That.
+//
+// Each check returns the Subject so that further checks can be chained. Once
+// a check fails, all subsequent checks are skipped, so only the first failure
+// is reported.
+type Subject[V any] struct {
+	t      T
+	label  string
+	got    V
+	result Result
+}
+
+// That returns a Subject for got, which allows several checks against the
+// same value to be written fluently, e.g.:
+//
+//	assert.That(t, user.Roles).Named("roles").NotNil().Contains("admin")
+//
+// Failures use the label "value" unless a different one is set with Named.
+func That[V any](t T, got V) Subject[V] {
+	return Subject[V]{t: t, label: "value", got: got, result: Result{t: t}}
+}
+
+// Named returns a copy of s that uses label in failure messages.
+func (s Subject[V]) Named(label string) Subject[V] {
+	s.label = label
+	return s
+}
+
+// Contains checks that the subject contains want. Strings are checked for a
+// substring, slices and arrays for an element, and maps for a key. Elements
+// and keys are compared using the rules of [reflect.DeepEqual].
+func (s Subject[V]) Contains(want any) Subject[V] {
+	s.t.Helper()
+	if s.result.failed {
+		return s
+	}
+
+	var found bool
+	rv := reflect.ValueOf(s.got)
+	switch rv.Kind() {
+	case reflect.String:
+		sub, ok := want.(string)
+		found = ok && strings.Contains(rv.String(), sub)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len() && !found; i++ {
+			found = reflect.DeepEqual(rv.Index(i).Interface(), want)
+		}
+	case reflect.Map:
+		for _, key := range rv.MapKeys() {
+			if reflect.DeepEqual(key.Interface(), want) {
+				found = true
+				break
+			}
+		}
+	default:
+		s.result = fail(s.t, s.label, "Expected %s to be a string, slice, array, or map, but got %T.", s.label, s.got)
+		return s
+	}
+
+	if !found {
+		s.result = fail(s.t, s.label, "Expected %s to contain %v, but got %v.", s.label, wantValue(want), gotValue(s.got))
+	} else {
+		s.result = pass(s.t)
+	}
+	return s
+}
+
+// Equals checks that the subject is "deeply equal" to want according to the
+// rules of [reflect.DeepEqual].
+func (s Subject[V]) Equals(want V) Subject[V] {
+	s.t.Helper()
+	if s.result.failed {
+		return s
+	}
+	if !reflect.DeepEqual(s.got, want) {
+		s.result = fail(s.t, s.label, "Expected %s to be %v, but got %v.", s.label, wantValue(want), gotValue(s.got))
+	} else {
+		s.result = pass(s.t)
+	}
+	return s
+}
+
+// NotNil checks that the subject is not nil. Values of types that can't be
+// nil always pass.
+func (s Subject[V]) NotNil() Subject[V] {
+	s.t.Helper()
+	if s.result.failed {
+		return s
+	}
+	if isNil(s.got) {
+		s.result = fail(s.t, s.label, "Expected %s to not be nil, but it was.", s.label)
+	} else {
+		s.result = pass(s.t)
+	}
+	return s
+}
+
+// Result returns the Result of the most recent check, which corresponds to
+// the first failure if any check failed. This allows a chain to be ended
+// with Fatal, e.g.:
+//
+//	assert.That(t, got).NotNil().Equals(want).Result().Fatal()
+func (s Subject[V]) Result() Result {
+	return s.result
+}
+
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice, reflect.UnsafePointer:
+		return rv.IsNil()
+	default:
+		return false
+	}
+}
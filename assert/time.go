@@ -0,0 +1,46 @@
+package assert
+
+import "time"
+
+// DurationWithin validates that got is within tolerance of want, inclusive.
+// This is useful for sanity-checking elapsed-time measurements, e.g.:
+//
+//	start := time.Now()
+//	worker.Run()
+//	assert.DurationWithin(t, "run time", 100*time.Millisecond, time.Since(start), 20*time.Millisecond)
+func DurationWithin(t T, label string, want, got, tolerance time.Duration) Result {
+	t.Helper()
+	delta := got - want
+	if delta < -tolerance || delta > tolerance {
+		return fail(t, label,
+			"Expected %s to be within %s of %s, but got %s (delta %s).",
+			label, tolerance, wantValue(want), gotValue(got), delta,
+		)
+	}
+	return pass(t)
+}
+
+// TimeEqual validates that two times represent the same instant after both
+// are normalized to UTC, stripped of any monotonic clock reading, and
+// truncated to a multiple of truncateTo. Pass a truncateTo of zero to compare
+// without truncation, or e.g. time.Millisecond to ignore precision lost when
+// round-tripping through a database or a serialization format.
+func TimeEqual(t T, label string, want, got time.Time, truncateTo time.Duration) Result {
+	t.Helper()
+	want, got = normalizeTime(want, truncateTo), normalizeTime(got, truncateTo)
+	if !want.Equal(got) {
+		return fail(t, label,
+			"Expected %s to be %s, but got %s.",
+			label, wantValue(want.Format(time.RFC3339Nano)), gotValue(got.Format(time.RFC3339Nano)),
+		)
+	}
+	return pass(t)
+}
+
+func normalizeTime(t time.Time, truncateTo time.Duration) time.Time {
+	t = t.Round(0).UTC()
+	if truncateTo > 0 {
+		t = t.Truncate(truncateTo)
+	}
+	return t
+}
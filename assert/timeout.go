@@ -0,0 +1,53 @@
+package assert
+
+import (
+	"strings"
+	"time"
+)
+
+// CompletesWithin validates that f returns within timeout. This is useful as
+// a guard against accidental deadlocks, e.g.:
+//
+//	assert.CompletesWithin(t, "shutdown", time.Second, func() {
+//		server.Shutdown(ctx)
+//	})
+//
+// f is run in a new goroutine. If it doesn't return in time, the stacks of the
+// running goroutines are included in the failure output to help locate the
+// deadlock. Note that f can't be stopped, so it continues to run in the
+// background after a timeout.
+func CompletesWithin(t T, label string, timeout time.Duration, f func()) Result {
+	t.Helper()
+
+	done := make(chan any, 1)
+	go func() {
+		// A deferred send ensures that we're notified even if f panics or exits
+		// the goroutine with runtime.Goexit, e.g. by calling t.FailNow.
+		var panicked any
+		defer func() { done <- panicked }()
+		defer func() { panicked = recover() }()
+		f()
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case p := <-done:
+		if p != nil {
+			return fail(t, label, "Expected %s to complete, but it panicked: %v.", label, p)
+		}
+		return pass(t)
+	case <-timer.C:
+		var stacks []string
+		for _, g := range goroutines() {
+			if !g.ignored() {
+				stacks = append(stacks, g.stack)
+			}
+		}
+		return fail(t, label,
+			"Expected %s to complete within %s, but it didn't. Running goroutines:\n\n%s",
+			label, timeout, strings.Join(stacks, "\n\n"),
+		)
+	}
+}
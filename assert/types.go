@@ -0,0 +1,84 @@
+package assert
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Implements validates that the dynamic type of value satisfies the interface
+// I. On failure, the methods of I that are missing from the type (or that have
+// the wrong signature) are listed. This is useful for guarding against
+// accidental interface breakage in libraries, e.g.:
+//
+//	assert.Implements[io.ReadCloser](t, "body", resp.Body)
+func Implements[I any](t T, label string, value any) Result {
+	t.Helper()
+
+	iface := reflect.TypeFor[I]()
+	if iface.Kind() != reflect.Interface {
+		return fail(t, label, "Expected %s to be an interface type, but it was %s.", iface, iface.Kind())
+	}
+	if value == nil {
+		return fail(t, label, "Expected %s to implement %s, but it was nil.", label, iface)
+	}
+
+	typ := reflect.TypeOf(value)
+	if typ.Implements(iface) {
+		return pass(t)
+	}
+
+	var missing []string
+	for i := 0; i < iface.NumMethod(); i++ {
+		want := iface.Method(i)
+		got, ok := typ.MethodByName(want.Name)
+		switch {
+		case !ok:
+			missing = append(missing, want.Name)
+		case !sameSignature(want.Type, got.Type):
+			missing = append(missing, want.Name+" (wrong signature)")
+		}
+	}
+	return fail(t, label,
+		"Expected %s to implement %s, but %s is missing methods: %s.",
+		label, iface, typ, strings.Join(missing, ", "),
+	)
+}
+
+// IsType validates that the dynamic type of got is E and returns got as an E so
+// that the test can continue without a manual type assertion, e.g.:
+//
+//	notFound, _ := assert.IsType[*NotFoundError](t, "error", err)
+//
+// If E is an interface type, the assertion succeeds if the dynamic type of got
+// implements E. On failure, the zero value of E is returned.
+func IsType[E any](t T, label string, got any) (E, Result) {
+	t.Helper()
+	e, ok := got.(E)
+	if !ok {
+		return e, fail(t, label, "Expected %s to be of type %s, but got %T.", label, reflect.TypeFor[E](), got)
+	}
+	return e, pass(t)
+}
+
+// sameSignature reports whether the method type of an interface method matches
+// the function type of a concrete method, which includes the receiver as its
+// first argument.
+func sameSignature(iface, concrete reflect.Type) bool {
+	if iface.NumIn() != concrete.NumIn()-1 || iface.NumOut() != concrete.NumOut() {
+		return false
+	}
+	if iface.IsVariadic() != concrete.IsVariadic() {
+		return false
+	}
+	for i := 0; i < iface.NumIn(); i++ {
+		if iface.In(i) != concrete.In(i+1) {
+			return false
+		}
+	}
+	for i := 0; i < iface.NumOut(); i++ {
+		if iface.Out(i) != concrete.Out(i) {
+			return false
+		}
+	}
+	return true
+}
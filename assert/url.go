@@ -0,0 +1,52 @@
+package assert
+
+import (
+	"net/mail"
+	"net/url"
+	"strings"
+)
+
+// ValidEmail validates that s is a bare email address such as
+// "bender@planetexpress.com". Addresses with a display name, e.g.
+// "Bender <bender@planetexpress.com>", are rejected.
+func ValidEmail(t T, label, s string) Result {
+	t.Helper()
+
+	local, domain, ok := strings.Cut(s, "@")
+	if !ok {
+		return fail(t, label, "Expected %s to contain an @, but got %q.", label, gotValue(s))
+	}
+	if local == "" {
+		return fail(t, label, "Expected %s to have a local part before the @, but got %q.", label, gotValue(s))
+	}
+	if domain == "" {
+		return fail(t, label, "Expected %s to have a domain after the @, but got %q.", label, gotValue(s))
+	}
+
+	addr, err := mail.ParseAddress(s)
+	if err != nil {
+		return fail(t, label, "Expected %s to be a valid email address, but got %q (%v).", label, gotValue(s), err)
+	}
+	if addr.Name != "" || addr.Address != s {
+		return fail(t, label, "Expected %s to be a bare email address, but got %q.", label, gotValue(s))
+	}
+	return pass(t)
+}
+
+// ValidURL validates that s is an absolute URL with both a scheme and a host,
+// e.g. "https://example.com/path".
+func ValidURL(t T, label, s string) Result {
+	t.Helper()
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return fail(t, label, "Expected %s to be a valid URL, but got %v.", label, err)
+	}
+	if u.Scheme == "" {
+		return fail(t, label, "Expected %s to have a scheme, but got %q.", label, gotValue(s))
+	}
+	if u.Host == "" {
+		return fail(t, label, "Expected %s to have a host, but got %q.", label, gotValue(s))
+	}
+	return pass(t)
+}
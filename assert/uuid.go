@@ -0,0 +1,60 @@
+package assert
+
+// ValidUUID validates that s is a UUID in its canonical textual form, e.g.
+// "f47ac10b-58cc-4372-a567-0e02b2c3d479". Hexadecimal digits may be upper or
+// lower case.
+//
+// If a version is provided, s must also be an RFC 9562 UUID of that version:
+//
+//	assert.ValidUUID(t, "user ID", user.ID, 4)
+func ValidUUID(t T, label, s string, version ...int) Result {
+	t.Helper()
+
+	if !isUUID(s) {
+		return fail(t, label, "Expected %s to be a valid UUID, but got %q.", label, gotValue(s))
+	}
+	if len(version) == 0 {
+		return pass(t)
+	}
+
+	if variant := hexDigit(s[19]); variant&0xc != 0x8 {
+		return fail(t, label, "Expected %s to be an RFC 9562 UUID, but got %q with variant bits %04b.", label, gotValue(s), variant)
+	}
+	if got := hexDigit(s[14]); got != version[0] {
+		return fail(t, label, "Expected %s to be a version %d UUID, but got version %d.", label, wantValue(version[0]), gotValue(got))
+	}
+	return pass(t)
+}
+
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return false
+			}
+		default:
+			if hexDigit(s[i]) < 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// hexDigit returns the value of the hexadecimal digit c, or -1 if c isn't one.
+func hexDigit(c byte) int {
+	switch {
+	case '0' <= c && c <= '9':
+		return int(c - '0')
+	case 'a' <= c && c <= 'f':
+		return int(c-'a') + 10
+	case 'A' <= c && c <= 'F':
+		return int(c-'A') + 10
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,125 @@
+package assert
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"time"
+)
+
+// A WebSocketConn is a WebSocket connection that messages can be read from.
+// It is deliberately minimal so that connections from any WebSocket package
+// can be used. Connections that use read deadlines, such as those from
+// github.com/gorilla/websocket, can be adapted with DeadlineConn, while
+// context-aware connections can be adapted with a WebSocketFunc, e.g. for
+// github.com/coder/websocket (formerly nhooyr.io/websocket):
+//
+//	conn := assert.WebSocketFunc(func(ctx context.Context) ([]byte, error) {
+//		_, msg, err := c.Read(ctx)
+//		return msg, err
+//	})
+type WebSocketConn interface {
+	// ReadMessage reads the next data message from the connection, returning
+	// an error if ctx is done first.
+	ReadMessage(ctx context.Context) ([]byte, error)
+}
+
+// WebSocketFunc is an adapter to allow the use of an ordinary function as a
+// WebSocketConn.
+type WebSocketFunc func(ctx context.Context) ([]byte, error)
+
+// ReadMessage calls f(ctx).
+func (f WebSocketFunc) ReadMessage(ctx context.Context) ([]byte, error) {
+	return f(ctx)
+}
+
+// DeadlineConn adapts a connection that uses read deadlines, such as a
+// *websocket.Conn from github.com/gorilla/websocket, to a WebSocketConn. The
+// deadline of the context passed to ReadMessage is used as the read deadline.
+func DeadlineConn(conn interface {
+	ReadMessage() (messageType int, p []byte, err error)
+	SetReadDeadline(t time.Time) error
+}) WebSocketConn {
+	return WebSocketFunc(func(ctx context.Context) ([]byte, error) {
+		deadline, _ := ctx.Deadline()
+		if err := conn.SetReadDeadline(deadline); err != nil {
+			return nil, err
+		}
+		_, msg, err := conn.ReadMessage()
+		if isTimeout(err) {
+			return nil, context.DeadlineExceeded
+		}
+		return msg, err
+	})
+}
+
+// ReceivesJSON validates that the next message received from conn within the
+// timeout is a JSON document that decodes to the value want points to, e.g.:
+//
+//	assert.ReceivesJSON(t, conn, &Event{Type: "joined", User: "Bender"}, time.Second)
+//
+// The message is decoded into a new value of the same type as *want and the
+// two are compared according to the rules of [reflect.DeepEqual].
+func ReceivesJSON(t T, conn WebSocketConn, want any, timeout time.Duration) Result {
+	t.Helper()
+	wantPtr := reflect.ValueOf(want)
+	if wantPtr.Kind() != reflect.Pointer || wantPtr.IsNil() {
+		return fail(t, "websocket", "Unexpected non-pointer want of type %T.", want)
+	}
+
+	msg, result := readWebSocketMessage(t, conn, timeout)
+	if !result.OK() {
+		return result
+	}
+
+	gotPtr := reflect.New(wantPtr.Type().Elem())
+	if err := json.Unmarshal(msg, gotPtr.Interface()); err != nil {
+		return fail(t, "websocket", "Expected websocket message to be JSON, but got %v.", err)
+	}
+	if got := gotPtr.Elem().Interface(); !reflect.DeepEqual(wantPtr.Elem().Interface(), got) {
+		return fail(t, "websocket", "Expected websocket message to be %v, but got %v.", wantValue(wantPtr.Elem().Interface()), gotValue(got))
+	}
+	return pass(t)
+}
+
+// ReceivesMessage validates that the next message received from conn within
+// the timeout is exactly want.
+func ReceivesMessage(t T, conn WebSocketConn, want string, timeout time.Duration) Result {
+	t.Helper()
+	msg, result := readWebSocketMessage(t, conn, timeout)
+	if !result.OK() {
+		return result
+	}
+	if got := string(msg); got != want {
+		return fail(t, "websocket", "Expected websocket message to be %q, but got %q.", wantValue(want), gotValue(got))
+	}
+	return pass(t)
+}
+
+// readWebSocketMessage reads the next message from conn. The returned Result
+// is only OK if a message was read, in which case no assertion has been
+// recorded yet.
+func readWebSocketMessage(t T, conn WebSocketConn, timeout time.Duration) ([]byte, Result) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	msg, err := conn.ReadMessage(ctx)
+	if errors.Is(err, context.DeadlineExceeded) || isTimeout(err) {
+		return nil, fail(t, "websocket", "Expected websocket to receive a message within %s, but it didn't.", timeout)
+	}
+	if err != nil {
+		return nil, fail(t, "websocket", "Unexpected error reading websocket message: %v.", err)
+	}
+	return msg, Result{t: t}
+}
+
+func isTimeout(err error) bool {
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		return true
+	}
+	var te interface{ Timeout() bool }
+	return errors.As(err, &te) && te.Timeout()
+}
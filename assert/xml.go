@@ -0,0 +1,134 @@
+package assert
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// XMLEqual validates that two strings contain equivalent XML documents. Both
+// documents are parsed and normalized before comparison so that the following
+// differences are ignored:
+//
+//   - the order of attributes
+//   - whitespace between elements
+//   - self-closing tags versus empty start and end tags
+//   - namespace prefixes, as long as they refer to the same namespace
+//   - comments, processing instructions, and directives
+//
+// On failure, the path to the first difference is reported.
+func XMLEqual(t T, label, want, got string) Result {
+	t.Helper()
+
+	wantRoot, err := parseXML(want)
+	if err != nil {
+		return fail(t, label, "Unexpected error parsing wanted %s as XML: %v.", label, err)
+	}
+	gotRoot, err := parseXML(got)
+	if err != nil {
+		return fail(t, label, "Expected %s to be valid XML, but got %v.", label, err)
+	}
+
+	if diff := diffXML("", wantRoot, gotRoot); diff != "" {
+		return fail(t, label, "Expected %s to be equivalent XML, but %s.", label, diff)
+	}
+	return pass(t)
+}
+
+type xmlNode struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	text     string
+	children []*xmlNode
+}
+
+func parseXML(s string) (*xmlNode, error) {
+	dec := xml.NewDecoder(strings.NewReader(s))
+	root := &xmlNode{}
+	stack := []*xmlNode{root}
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		parent := stack[len(stack)-1]
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			node := &xmlNode{name: tok.Name}
+			for _, attr := range tok.Attr {
+				if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+					continue
+				}
+				node.attrs = append(node.attrs, attr)
+			}
+			sort.Slice(node.attrs, func(i, j int) bool {
+				return xmlName(node.attrs[i].Name) < xmlName(node.attrs[j].Name)
+			})
+			parent.children = append(parent.children, node)
+			stack = append(stack, node)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if strings.TrimSpace(string(tok)) != "" {
+				parent.text += string(tok)
+			}
+		}
+	}
+	return root, nil
+}
+
+func diffXML(path string, want, got *xmlNode) string {
+	if want.name != got.name {
+		return fmt.Sprintf("%s is %s instead of %s", displayXMLPath(path), xmlName(got.name), xmlName(want.name))
+	}
+	if len(want.attrs) != len(got.attrs) {
+		return fmt.Sprintf("%s has attributes %s instead of %s", displayXMLPath(path), formatAttrs(got.attrs), formatAttrs(want.attrs))
+	}
+	for i := range want.attrs {
+		if want.attrs[i] != got.attrs[i] {
+			return fmt.Sprintf("%s has attributes %s instead of %s", displayXMLPath(path), formatAttrs(got.attrs), formatAttrs(want.attrs))
+		}
+	}
+	if want.text != got.text {
+		return fmt.Sprintf("%s has text %q instead of %q", displayXMLPath(path), got.text, want.text)
+	}
+	if len(want.children) != len(got.children) {
+		return fmt.Sprintf("%s has %d child elements instead of %d", displayXMLPath(path), len(got.children), len(want.children))
+	}
+	for i := range want.children {
+		childPath := fmt.Sprintf("%s/%s[%d]", path, xmlName(want.children[i].name), i+1)
+		if diff := diffXML(childPath, want.children[i], got.children[i]); diff != "" {
+			return diff
+		}
+	}
+	return ""
+}
+
+func xmlName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return "{" + name.Space + "}" + name.Local
+}
+
+func formatAttrs(attrs []xml.Attr) string {
+	strs := make([]string, len(attrs))
+	for i, attr := range attrs {
+		strs[i] = fmt.Sprintf("%s=%q", xmlName(attr.Name), attr.Value)
+	}
+	return "[" + strings.Join(strs, " ") + "]"
+}
+
+func displayXMLPath(path string) string {
+	if path == "" {
+		return "the document"
+	}
+	return path
+}
@@ -0,0 +1,31 @@
+package assert
+
+import (
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLEqual validates that two strings contain equivalent YAML documents. Both
+// documents are parsed before comparison, so differences in formatting,
+// quoting style, comments, and key order are ignored, e.g.:
+//
+//	assert.YAMLEqual(t, "config", "name: bender\nport: 80", "port: 80\nname: 'bender'")
+//
+// Only the first document in each string is compared.
+func YAMLEqual(t T, label, want, got string) Result {
+	t.Helper()
+
+	var wantDoc, gotDoc any
+	if err := yaml.Unmarshal([]byte(want), &wantDoc); err != nil {
+		return fail(t, label, "Unexpected error parsing wanted %s as YAML: %v.", label, err)
+	}
+	if err := yaml.Unmarshal([]byte(got), &gotDoc); err != nil {
+		return fail(t, label, "Expected %s to be valid YAML, but got %v.", label, err)
+	}
+
+	if !reflect.DeepEqual(wantDoc, gotDoc) {
+		return fail(t, label, "Expected %s to be %v, but got %v.", label, wantValue(wantDoc), gotValue(gotDoc))
+	}
+	return pass(t)
+}
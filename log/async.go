@@ -0,0 +1,150 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+)
+
+// An OverflowPolicy determines what an asynchronous logger does with a log
+// line when its buffer is full.
+type OverflowPolicy int
+
+const (
+	// Block waits for space in the buffer, so no lines are lost but logging
+	// can slow down callers while the output catches up.
+	Block OverflowPolicy = iota
+
+	// Drop discards the line, so logging never waits on the output but lines
+	// can be lost during bursts.
+	Drop
+)
+
+// WithAsync configures a logger to write log lines in the background so that
+// slow outputs don't add latency to callers. Up to size lines are buffered and
+// policy determines what happens when the buffer is full.
+//
+// Lines that are still buffered when the program exits are lost, so
+// asynchronous loggers should be closed before exiting, e.g.:
+//
+//	logger := log.New(log.WithAsync(1024, log.Block))
+//	defer logger.Close()
+//
+// Errors writing to the output are reported by Flush and Close.
+func WithAsync(size int, policy OverflowPolicy) Option {
+	return func(cfg *config) {
+		cfg.async = &asyncConfig{size: size, policy: policy}
+	}
+}
+
+type asyncConfig struct {
+	size   int
+	policy OverflowPolicy
+}
+
+// asyncItem is either a line to write or, if flushed is non-nil, a request to
+// be notified once all of the lines queued before it are written.
+type asyncItem struct {
+	line    []byte
+	flushed chan error
+}
+
+// asyncWriter queues writes and performs them on a background goroutine.
+type asyncWriter struct {
+	w      io.Writer
+	policy OverflowPolicy
+	queue  chan asyncItem
+	done   chan struct{}
+
+	// mu guards closed and policy. Writers hold a read lock while queueing so
+	// that the queue isn't closed out from under them.
+	mu     sync.RWMutex
+	closed bool
+
+	// errs is only accessed by the background goroutine.
+	errs []error
+}
+
+func newAsyncWriter(w io.Writer, cfg *asyncConfig) *asyncWriter {
+	a := &asyncWriter{
+		w:      w,
+		policy: cfg.policy,
+		queue:  make(chan asyncItem, cfg.size),
+		done:   make(chan struct{}),
+	}
+	go a.run()
+	return a
+}
+
+func (a *asyncWriter) run() {
+	defer close(a.done)
+	for item := range a.queue {
+		if item.flushed != nil {
+			item.flushed <- errors.Join(a.errs...)
+			a.errs = nil
+			continue
+		}
+		if _, err := a.w.Write(item.line); err != nil {
+			a.errs = append(a.errs, err)
+		}
+	}
+}
+
+// Write queues p to be written. Once the writer is closed, writes go directly
+// to the underlying writer.
+func (a *asyncWriter) Write(p []byte) (int, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.closed {
+		return a.w.Write(p)
+	}
+
+	item := asyncItem{line: bytes.Clone(p)}
+	if a.policy == Drop {
+		select {
+		case a.queue <- item:
+		default:
+		}
+		return len(p), nil
+	}
+	a.queue <- item
+	return len(p), nil
+}
+
+// block makes subsequent writes wait for space in the buffer regardless of the
+// overflow policy.
+func (a *asyncWriter) block() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.policy = Block
+}
+
+// Flush waits for all queued lines to be written and returns any errors that
+// occurred writing them.
+func (a *asyncWriter) Flush() error {
+	a.mu.RLock()
+	if a.closed {
+		a.mu.RUnlock()
+		return nil
+	}
+	flushed := make(chan error, 1)
+	a.queue <- asyncItem{flushed: flushed}
+	a.mu.RUnlock()
+	return <-flushed
+}
+
+// Close writes all queued lines and stops the background goroutine.
+func (a *asyncWriter) Close() error {
+	err := a.Flush()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed {
+		return err
+	}
+	a.closed = true
+	close(a.queue)
+	<-a.done
+	return errors.Join(append([]error{err}, a.errs...)...)
+}
@@ -0,0 +1,106 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// boundHandler keeps the attributes and groups bound with WithAttrs and
+// WithGroup itself instead of passing them on, and adds them to each record
+// along with the attributes stored in the context by ContextWithAttrs. This
+// means the handlers it wraps see every attribute on the record, so they can
+// add attributes at the top level regardless of any groups, and records that
+// differ only in their bound attributes can be told apart.
+//
+// boundHandler also sets the time of each record using the logger's clock and
+// adds stack traces, so that these apply to records logged through the
+// slog.Logger returned by Slog as well.
+type boundHandler struct {
+	next slog.Handler
+
+	clock           func() time.Time
+	stackTrace      bool
+	stackTraceLevel slog.Level
+
+	// name is the name of the logger set by Named, which is written at the
+	// top level regardless of any groups.
+	name string
+
+	// scopes holds the attributes bound at each level of nesting. The first
+	// scope is the top level and has no name; each subsequent scope is a group.
+	scopes []scope
+}
+
+type scope struct {
+	group string
+	attrs []slog.Attr
+}
+
+func newBoundHandler(next slog.Handler, cfg *config) *boundHandler {
+	return &boundHandler{
+		next:            next,
+		clock:           cfg.clock,
+		stackTrace:      cfg.stackTrace,
+		stackTraceLevel: cfg.stackTraceLevel,
+		scopes:          []scope{{}},
+	}
+}
+
+func (h *boundHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *boundHandler) Handle(ctx context.Context, r slog.Record) error {
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	attrs = append(attrs, attrsFromContext(ctx)...)
+
+	for i := len(h.scopes) - 1; i > 0; i-- {
+		s := h.scopes[i]
+		group := append(s.attrs[:len(s.attrs):len(s.attrs)], attrs...)
+		attrs = []slog.Attr{{Key: s.group, Value: slog.GroupValue(group...)}}
+	}
+	top := h.scopes[0].attrs
+	if h.name != "" {
+		top = append([]slog.Attr{slog.String("logger", h.name)}, top...)
+	}
+	attrs = append(top[:len(top):len(top)], attrs...)
+	if h.stackTrace && r.Level >= h.stackTraceLevel && !hasAttr(r, "stack") {
+		attrs = append(attrs, slog.String("stack", stackTraceAt(r.PC)))
+	}
+
+	r2 := slog.NewRecord(h.clock(), r.Level, r.Message, r.PC)
+	r2.AddAttrs(attrs...)
+	return h.next.Handle(ctx, r2)
+}
+
+func (h *boundHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.scopes = append([]scope{}, h.scopes...)
+	last := &h2.scopes[len(h2.scopes)-1]
+	last.attrs = append(last.attrs[:len(last.attrs):len(last.attrs)], attrs...)
+	return &h2
+}
+
+func (h *boundHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.scopes = append(h.scopes[:len(h.scopes):len(h.scopes)], scope{group: name})
+	return &h2
+}
+
+// withName returns a copy of h for the logger with the given name.
+func (h *boundHandler) withName(name string) *boundHandler {
+	h2 := *h
+	h2.name = name
+	return &h2
+}
@@ -0,0 +1,80 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type attrsKey struct{}
+
+// ContextWithAttrs returns a copy of ctx that carries the given attributes in
+// addition to any already stored in ctx. Every line emitted by a Logger with
+// the returned context, or any context derived from it, includes these
+// attributes. This allows middleware to attach request metadata once, e.g.:
+//
+//	ctx = log.ContextWithAttrs(r.Context(), "method", r.Method, "path", r.URL.Path)
+//	next.ServeHTTP(w, r.WithContext(ctx))
+//
+// The arguments are interpreted as for the Debug, Info, Warn, and Error
+// methods of Logger. Like attributes passed to those methods, they are nested
+// under any groups added to the logger with WithGroup.
+func ContextWithAttrs(ctx context.Context, args ...any) context.Context {
+	// Adding the arguments to a record is the simplest way to convert them to
+	// attributes using the same rules as slog.
+	r := slog.NewRecord(time.Time{}, 0, "", 0)
+	r.Add(args...)
+
+	existing := attrsFromContext(ctx)
+	attrs := make([]slog.Attr, 0, len(existing)+r.NumAttrs())
+	attrs = append(attrs, existing...)
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	return context.WithValue(ctx, attrsKey{}, attrs)
+}
+
+func attrsFromContext(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+	attrs, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	return attrs
+}
+
+// WithContextAttrsFunc configures a logger to call f with the context passed to
+// each logging method and include the returned attributes in the log line.
+// This is useful for integrating with packages that store their own metadata
+// in the context, such as tracing libraries. The attributes are treated as
+// correlation IDs, so unlike those added with ContextWithAttrs, they're always
+// written at the top level of the log line, even by loggers with groups. f
+// must be safe for concurrent use and should return nil if the context
+// doesn't contain anything relevant.
+func WithContextAttrsFunc(f func(ctx context.Context) []slog.Attr) Option {
+	return func(cfg *config) {
+		cfg.contextAttrs = append(cfg.contextAttrs, f)
+	}
+}
+
+// contextHandler adds the attributes returned by extract for the context to
+// each record.
+type contextHandler struct {
+	slog.Handler
+	extract func(context.Context) []slog.Attr
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if attrs := h.extract(ctx); len(attrs) > 0 {
+		r.AddAttrs(attrs...)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs), h.extract}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name), h.extract}
+}
@@ -0,0 +1,141 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WithDedup configures a logger to collapse bursts of identical consecutive
+// records into a single record. Records are identical if they have the same
+// level, message, and attributes, including those bound with With and
+// WithGroup and those from the context. Each record is held for up to window while
+// waiting for duplicates, and is then written once with a "repeat_count"
+// attribute containing the number of times it was logged if that is more than
+// one, e.g.:
+//
+//	{"level":"ERROR","msg":"query failed","repeat_count":5000}
+//
+// Unlike WithSampling, no records are lost and the exact count is preserved.
+// Held records are written when a different record is logged, when the window
+// ends, and when Flush, Close, or Fatal is called.
+func WithDedup(window time.Duration) Option {
+	return func(cfg *config) {
+		cfg.dedup = &dedupState{window: window}
+	}
+}
+
+// dedupState holds the record waiting for duplicates. It is shared by a
+// dedupHandler and all of the handlers derived from it.
+type dedupState struct {
+	window time.Duration
+
+	mu      sync.Mutex
+	pending *pendingRecord
+	timer   *time.Timer
+}
+
+type pendingRecord struct {
+	ctx     context.Context
+	handler slog.Handler
+	record  slog.Record
+	key     string
+	count   int
+}
+
+// Flush writes the pending record, if any.
+func (s *dedupState) Flush() error {
+	s.mu.Lock()
+	p := s.take()
+	s.mu.Unlock()
+	return p.write()
+}
+
+// expire writes p if it is still the pending record. It is called when the
+// window for p ends, by which time p may already have been replaced.
+func (s *dedupState) expire(p *pendingRecord) {
+	s.mu.Lock()
+	if s.pending != p {
+		s.mu.Unlock()
+		return
+	}
+	s.take()
+	s.mu.Unlock()
+	_ = p.write()
+}
+
+// take removes and returns the pending record. The caller must hold mu.
+func (s *dedupState) take() *pendingRecord {
+	p := s.pending
+	s.pending = nil
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	return p
+}
+
+func (p *pendingRecord) write() error {
+	if p == nil {
+		return nil
+	}
+	r := p.record
+	if p.count > 1 {
+		r.AddAttrs(slog.Int("repeat_count", p.count))
+	}
+	return p.handler.Handle(p.ctx, r)
+}
+
+// dedupHandler collapses identical consecutive records.
+type dedupHandler struct {
+	slog.Handler
+	state *dedupState
+}
+
+func (h dedupHandler) Handle(ctx context.Context, r slog.Record) error {
+	key := dedupKey(r)
+
+	h.state.mu.Lock()
+	if p := h.state.pending; p != nil && p.key == key {
+		p.count++
+		h.state.mu.Unlock()
+		return nil
+	}
+	prev := h.state.take()
+	p := &pendingRecord{
+		ctx:     ctx,
+		handler: h.Handler,
+		record:  r.Clone(),
+		key:     key,
+		count:   1,
+	}
+	h.state.pending = p
+	h.state.timer = time.AfterFunc(h.state.window, func() { h.state.expire(p) })
+	h.state.mu.Unlock()
+
+	return prev.write()
+}
+
+func (h dedupHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return dedupHandler{h.Handler.WithAttrs(attrs), h.state}
+}
+
+func (h dedupHandler) WithGroup(name string) slog.Handler {
+	return dedupHandler{h.Handler.WithGroup(name), h.state}
+}
+
+// dedupKey returns a string identifying the level, message, and attributes of
+// r. Since dedupHandler runs after the attributes bound to the logger and those
+// from the context have been added to r, they are part of the key.
+func dedupKey(r slog.Record) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d %q", r.Level, r.Message)
+	r.Attrs(func(a slog.Attr) bool {
+		fmt.Fprintf(&b, " %s", a)
+		return true
+	})
+	return b.String()
+}
@@ -0,0 +1,98 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// ecsVersion is the version of the Elastic Common Schema that the ECS format
+// conforms to.
+const ecsVersion = "8.11.0"
+
+// ecsReplaceAttr renames the built-in attributes to match the
+// Elastic Common Schema. See https://www.elastic.co/guide/en/ecs/current.
+func ecsReplaceAttr(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return a
+	}
+	switch a.Key {
+	case slog.TimeKey:
+		a.Key = "@timestamp"
+		return a
+	case slog.LevelKey:
+		a.Key = "log.level"
+		if level, ok := a.Value.Any().(slog.Level); ok {
+			a.Value = slog.StringValue(strings.ToLower(levelString(level)))
+		}
+		return a
+	case slog.MessageKey:
+		a.Key = "message"
+		return a
+	case slog.SourceKey:
+		if src, ok := a.Value.Any().(*slog.Source); ok {
+			return slog.Group("log.origin",
+				slog.String("function", src.Function),
+				slog.Group("file",
+					slog.String("name", src.File),
+					slog.Int("line", src.Line),
+				),
+			)
+		}
+		return a
+	case "stack":
+		a.Key = "error.stack_trace"
+		return a
+	}
+	return a
+}
+
+// ecsHandler writes the top-level "error" attribute as the ECS error fields.
+// This can't be done by ecsReplaceAttr since ReplaceAttr isn't called for
+// groups, such as those returned by Err.
+type ecsHandler struct {
+	slog.Handler
+}
+
+func (h ecsHandler) Handle(ctx context.Context, r slog.Record) error {
+	r2 := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		r2.AddAttrs(ecsError(a)...)
+		return true
+	})
+	return h.Handler.Handle(ctx, r2)
+}
+
+func (h ecsHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return ecsHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h ecsHandler) WithGroup(name string) slog.Handler {
+	return ecsHandler{h.Handler.WithGroup(name)}
+}
+
+// ecsError returns the ECS fields for a, if it is an "error" attribute whose
+// value is an error or a group such as the one returned by Err, or a unchanged
+// otherwise.
+func ecsError(a slog.Attr) []slog.Attr {
+	if a.Key != "error" {
+		return []slog.Attr{a}
+	}
+	a.Value = a.Value.Resolve()
+	switch v := a.Value.Any().(type) {
+	case error:
+		return []slog.Attr{
+			slog.String("error.message", v.Error()),
+			slog.String("error.type", fmt.Sprintf("%T", v)),
+		}
+	case []slog.Attr:
+		attrs := make([]slog.Attr, len(v))
+		for i, ga := range v {
+			ga.Key = "error." + ga.Key
+			attrs[i] = ga
+		}
+		return attrs
+	}
+	return []slog.Attr{a}
+}
@@ -0,0 +1,61 @@
+package log
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// Err returns an attribute describing err for passing to the logging methods,
+// e.g.:
+//
+//	logger.Error(ctx, "failed to fetch robot", log.Err(err))
+//
+// The attribute is a group named "error" containing the error message, the
+// concrete type of err, and a chain of the messages and types of every error
+// that err wraps, so that errors can be queried by type or cause:
+//
+//	"error":{"message":"fetch robot: not found","type":"*fmt.wrapError","chain":[{"message":"not found","type":"*errors.errorString"}]}
+//
+// Errors that wrap multiple errors are traversed depth-first. If err is nil,
+// Err returns an empty attribute, which is omitted from the output.
+func Err(err error) slog.Attr {
+	if err == nil {
+		return slog.Attr{}
+	}
+	attrs := []any{
+		slog.String("message", err.Error()),
+		slog.String("type", fmt.Sprintf("%T", err)),
+	}
+	if chain := errorChain(err); len(chain) > 0 {
+		attrs = append(attrs, slog.Any("chain", chain))
+	}
+	return slog.Group("error", attrs...)
+}
+
+type chainedError struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+}
+
+// errorChain returns the errors wrapped by err, not including err itself.
+func errorChain(err error) []chainedError {
+	var wrapped []error
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		if e := u.Unwrap(); e != nil {
+			wrapped = []error{e}
+		}
+	case interface{ Unwrap() []error }:
+		wrapped = u.Unwrap()
+	}
+
+	var chain []chainedError
+	for _, e := range wrapped {
+		if e == nil {
+			continue
+		}
+		chain = append(chain, chainedError{Message: e.Error(), Type: fmt.Sprintf("%T", e)})
+		chain = append(chain, errorChain(e)...)
+	}
+	return chain
+}
@@ -0,0 +1,67 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LevelFatal is the level of log lines emitted by Fatal. It is written as
+// "FATAL".
+const LevelFatal = slog.LevelError + 4
+
+// Fatal emits a log line at the fatal level, flushes any buffered log lines,
+// and then exits the program. This is intended for unrecoverable failures
+// during startup, e.g.:
+//
+//	db, err := sqlite.Open(path)
+//	if err != nil {
+//		logger.Fatal(ctx, "failed to open database", log.Err(err))
+//	}
+//
+// The fatal line is never dropped by an asynchronous logger, even if it uses
+// the Drop policy; from the time Fatal is called, the logger waits for space
+// in the buffer instead. By default, Fatal calls [os.Exit] with a status of 1.
+// Use WithExitCode and WithExitFunc to change this.
+func (l *Logger) Fatal(ctx context.Context, msg string, args ...any) {
+	if l.async != nil {
+		l.async.block()
+	}
+	l.log(ctx, LevelFatal, msg, args...)
+	_ = l.Flush()
+	l.exit(l.exitCode)
+}
+
+// WithExitCode configures the status that Fatal exits with. The default is 1.
+func WithExitCode(code int) Option {
+	return func(cfg *config) {
+		cfg.exitCode = code
+	}
+}
+
+// WithExitFunc configures a logger to call exit instead of [os.Exit] after
+// logging a fatal message. This allows tests to verify that Fatal was called
+// without exiting the test binary. If exit returns, so does Fatal.
+func WithExitFunc(exit func(code int)) Option {
+	return func(cfg *config) {
+		cfg.exit = exit
+	}
+}
+
+// levelString returns the name of level as written in log lines.
+func levelString(level slog.Level) string {
+	if level == LevelFatal {
+		return "FATAL"
+	}
+	return level.String()
+}
+
+// levelReplaceAttr writes the names of custom levels.
+func levelReplaceAttr(groups []string, a slog.Attr) slog.Attr {
+	if a.Key != slog.LevelKey || len(groups) > 0 {
+		return a
+	}
+	if level, ok := a.Value.Any().(slog.Level); ok {
+		a.Value = slog.StringValue(levelString(level))
+	}
+	return a
+}
@@ -0,0 +1,211 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is the format of the timestamp added to the names of
+// rotated files. It sorts lexically in chronological order.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// Rotation controls when a RotatingFile is rotated and how many rotated files
+// are kept. The zero value never rotates.
+type Rotation struct {
+	// MaxSize is the size in bytes at which the file is rotated. A single write
+	// is never split, so a file can exceed MaxSize if one log line is larger
+	// than MaxSize. If MaxSize is zero, the file is never rotated based on its
+	// size.
+	MaxSize int64
+
+	// MaxAge is the amount of time after which the file is rotated, measured
+	// from when it was opened. If MaxAge is zero, the file is never rotated
+	// based on its age.
+	MaxAge time.Duration
+
+	// MaxBackups is the maximum number of rotated files to keep. The oldest
+	// files are removed first. If MaxBackups is zero, all rotated files are
+	// kept.
+	MaxBackups int
+}
+
+// WithFile configures a logger to write to the file at path, rotating it
+// according to rotation, e.g.:
+//
+//	logger := log.New(log.WithFile("/var/log/app.log", log.Rotation{
+//		MaxSize:    100 << 20,
+//		MaxAge:     24 * time.Hour,
+//		MaxBackups: 7,
+//	}))
+//
+// This is a shortcut for passing the result of NewRotatingFile to WithOutput.
+// Use that instead if the file needs to be closed before the program exits.
+func WithFile(path string, rotation Rotation) Option {
+	return WithOutput(NewRotatingFile(path, rotation))
+}
+
+// A RotatingFile is an io.WriteCloser that writes to a file and moves it aside
+// when it grows too large or too old. Rotated files are renamed by adding the
+// time of rotation to the name before the extension, e.g. "app.log" becomes
+// "app-2024-02-01T12-01-32.000.log".
+//
+// A RotatingFile is safe for concurrent use.
+type RotatingFile struct {
+	path     string
+	rotation Rotation
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	opened time.Time
+}
+
+// NewRotatingFile creates a RotatingFile that writes to the file at path. The
+// file is opened, and created if necessary, on the first write, and is
+// appended to if it already exists.
+func NewRotatingFile(path string, rotation Rotation) *RotatingFile {
+	return &RotatingFile{path: path, rotation: rotation}
+}
+
+// Close closes the file. A subsequent write reopens it.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.close()
+}
+
+// Rotate closes the current file, renames it, opens a new file in its place,
+// and then removes any rotated files in excess of MaxBackups.
+func (f *RotatingFile) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rotate()
+}
+
+// Write implements the io.Writer interface.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	tooBig := f.rotation.MaxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.rotation.MaxSize
+	tooOld := f.rotation.MaxAge > 0 && time.Since(f.opened) >= f.rotation.MaxAge
+	if tooBig || tooOld {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return fmt.Errorf("log: open file: %w", err)
+	}
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("log: open file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("log: open file: %w", err)
+	}
+	f.file = file
+	f.size = info.Size()
+	f.opened = time.Now()
+	return nil
+}
+
+func (f *RotatingFile) close() error {
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *RotatingFile) rotate() error {
+	if err := f.close(); err != nil {
+		return fmt.Errorf("log: rotate file: %w", err)
+	}
+	if err := os.Rename(f.path, f.backupName(time.Now())); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("log: rotate file: %w", err)
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	if err := f.prune(); err != nil {
+		return fmt.Errorf("log: rotate file: %w", err)
+	}
+	return nil
+}
+
+// backupName returns an unused name for a file rotated at t.
+func (f *RotatingFile) backupName(t time.Time) string {
+	prefix, ext := f.backupPattern()
+	name := prefix + t.Format(backupTimeFormat) + ext
+	for i := 1; ; i++ {
+		if _, err := os.Stat(name); os.IsNotExist(err) {
+			return name
+		}
+		name = fmt.Sprintf("%s%s_%d%s", prefix, t.Format(backupTimeFormat), i, ext)
+	}
+}
+
+// backupPattern returns the prefix and suffix shared by the names of all
+// rotated files.
+func (f *RotatingFile) backupPattern() (prefix, ext string) {
+	ext = filepath.Ext(f.path)
+	return strings.TrimSuffix(f.path, ext) + "-", ext
+}
+
+// prune removes the oldest rotated files in excess of MaxBackups.
+func (f *RotatingFile) prune() error {
+	if f.rotation.MaxBackups <= 0 {
+		return nil
+	}
+
+	prefix, ext := f.backupPattern()
+	dir, prefix := filepath.Dir(prefix), filepath.Base(prefix)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	var backups []string
+	for _, entry := range entries {
+		stamp, ok := strings.CutPrefix(entry.Name(), prefix)
+		if !ok || !strings.HasSuffix(stamp, ext) || len(stamp) < len(backupTimeFormat) {
+			continue
+		}
+		if _, err := time.Parse(backupTimeFormat, stamp[:len(backupTimeFormat)]); err != nil {
+			continue
+		}
+		backups = append(backups, entry.Name())
+	}
+	if len(backups) <= f.rotation.MaxBackups {
+		return nil
+	}
+
+	slices.Sort(backups)
+	for _, name := range backups[:len(backups)-f.rotation.MaxBackups] {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package log
+
+import (
+	"log/slog"
+)
+
+// WithGCPProject configures a logger using the GCP format to qualify trace IDs
+// with the given Google Cloud project ID, as required for Cloud Logging to
+// link log entries with traces in Cloud Trace.
+func WithGCPProject(id string) Option {
+	return func(cfg *config) {
+		cfg.gcpProject = id
+	}
+}
+
+// gcpReplaceAttr returns a ReplaceAttr function that renames the built-in
+// attributes to match the special fields recognized by Google Cloud Logging.
+// See https://cloud.google.com/logging/docs/structured-logging.
+func gcpReplaceAttr(project string) func(groups []string, a slog.Attr) slog.Attr {
+	return func(groups []string, a slog.Attr) slog.Attr {
+		if len(groups) > 0 {
+			return a
+		}
+		switch a.Key {
+		case slog.TimeKey:
+			a.Key = "timestamp"
+		case slog.LevelKey:
+			a.Key = "severity"
+			if level, ok := a.Value.Any().(slog.Level); ok {
+				a.Value = slog.StringValue(gcpSeverity(level))
+			}
+		case slog.MessageKey:
+			a.Key = "message"
+		case slog.SourceKey:
+			a.Key = "logging.googleapis.com/sourceLocation"
+		case "trace_id":
+			a.Key = "logging.googleapis.com/trace"
+			if project != "" {
+				a.Value = slog.StringValue("projects/" + project + "/traces/" + a.Value.String())
+			}
+		case "span_id":
+			a.Key = "logging.googleapis.com/spanId"
+		}
+		return a
+	}
+}
+
+// gcpSeverity maps a log level to a Cloud Logging severity.
+func gcpSeverity(level slog.Level) string {
+	switch {
+	case level > slog.LevelError:
+		return "CRITICAL"
+	case level == slog.LevelError:
+		return "ERROR"
+	case level >= slog.LevelWarn:
+		return "WARNING"
+	case level >= slog.LevelInfo:
+		return "INFO"
+	default:
+		return "DEBUG"
+	}
+}
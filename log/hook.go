@@ -0,0 +1,54 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+)
+
+// A Hook is called with each record before it is written. Hooks may modify the
+// record, e.g. by adding attributes with [slog.Record.AddAttrs].
+type Hook func(ctx context.Context, r *slog.Record)
+
+// WithHook configures a logger to call hook with each record before it is
+// written. This allows applications to handle cross-cutting concerns in one
+// place, such as incrementing metrics or forwarding errors to an alerting
+// system, e.g.:
+//
+//	logger := log.New(log.WithHook(func(ctx context.Context, r *slog.Record) {
+//		if r.Level >= slog.LevelError {
+//			alerts.Notify(ctx, r.Message)
+//		}
+//	}))
+//
+// The record includes the attributes passed to the logging method, those
+// added with With and WithGroup, and those from the context, with the values
+// of any keys set with WithRedactedKeys already redacted. Hooks are called
+// in the order they were added and aren't called for lines below the logger's
+// level or dropped by WithSampling. Hooks must be safe for concurrent use.
+func WithHook(hook Hook) Option {
+	return func(cfg *config) {
+		cfg.hooks = append(cfg.hooks, hook)
+	}
+}
+
+// hookHandler calls its hooks with each record before passing it to its
+// handler.
+type hookHandler struct {
+	slog.Handler
+	hooks []Hook
+}
+
+func (h hookHandler) Handle(ctx context.Context, r slog.Record) error {
+	for _, hook := range h.hooks {
+		hook(ctx, &r)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h hookHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return hookHandler{h.Handler.WithAttrs(attrs), h.hooks}
+}
+
+func (h hookHandler) WithGroup(name string) slog.Handler {
+	return hookHandler{h.Handler.WithGroup(name), h.hooks}
+}
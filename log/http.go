@@ -0,0 +1,195 @@
+package log
+
+import (
+	"bufio"
+	"context"
+	crand "crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"runtime"
+	"time"
+)
+
+// RequestIDHeader is the header used by Middleware to read and propagate
+// request IDs.
+const RequestIDHeader = "X-Request-ID"
+
+// A MiddlewareOption modifies the behavior of the handler returned by
+// Middleware.
+type MiddlewareOption func(*middlewareConfig)
+
+type middlewareConfig struct {
+	skip       map[string]bool
+	sampleRate float64
+}
+
+// SkipPaths configures Middleware not to log requests for the given paths,
+// such as health checks. Paths must match exactly.
+func SkipPaths(paths ...string) MiddlewareOption {
+	return func(cfg *middlewareConfig) {
+		for _, path := range paths {
+			cfg.skip[path] = true
+		}
+	}
+}
+
+// SampleSuccesses configures Middleware to log only the given fraction of
+// requests that complete with a status below 400, e.g. 0.1 logs roughly one
+// in ten. Requests that fail are always logged.
+func SampleSuccesses(rate float64) MiddlewareOption {
+	return func(cfg *middlewareConfig) {
+		cfg.sampleRate = rate
+	}
+}
+
+// Middleware returns HTTP middleware that logs a line for every request after
+// it completes, e.g.:
+//
+//	handler := log.Middleware(logger, log.SkipPaths("/healthz"))(mux)
+//
+// Each line includes the method, path, status, duration, number of bytes
+// written, remote IP address, and request ID. Requests that fail with a 5xx
+// status are logged at the error level, 4xx at the warn level, and the rest
+// at the info level. Requests whose handler panics are still logged before
+// the panic continues, with a 500 status unless a response was already
+// started.
+//
+// The request ID is taken from the request context if it was added with
+// ContextWithRequestID, then from the X-Request-ID header. If neither is set,
+// a random ID is generated. The ID is added to the request context, so that
+// lines logged by the handler with a logger using WithRequestID include it,
+// and is set as the X-Request-ID header of the response.
+func Middleware(logger *Logger, opts ...MiddlewareOption) func(http.Handler) http.Handler {
+	cfg := middlewareConfig{skip: make(map[string]bool), sampleRate: 1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			id, ok := RequestIDFromContext(ctx)
+			if !ok {
+				id = r.Header.Get(RequestIDHeader)
+				if id == "" {
+					id = newRequestID()
+				}
+				ctx = ContextWithRequestID(ctx, id)
+				r = r.WithContext(ctx)
+			}
+			w.Header().Set(RequestIDHeader, id)
+
+			start := logger.clock()
+			rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+			completed := false
+			defer func() {
+				// If the handler panicked without writing a response, net/http
+				// aborts the connection, so report it as a server error.
+				if !completed && !rw.wroteHeader {
+					rw.status = http.StatusInternalServerError
+				}
+				logRequest(ctx, logger, &cfg, r, rw, id, logger.clock().Sub(start))
+			}()
+			next.ServeHTTP(rw, r)
+			completed = true
+		})
+	}
+}
+
+// logRequest logs a line describing a request that was handled by Middleware.
+func logRequest(ctx context.Context, logger *Logger, cfg *middlewareConfig, r *http.Request, rw *responseWriter, id string, duration time.Duration) {
+	if cfg.skip[r.URL.Path] {
+		return
+	}
+	if rw.status < 400 && cfg.sampleRate < 1 && rand.Float64() >= cfg.sampleRate {
+		return
+	}
+
+	level := slog.LevelInfo
+	switch {
+	case rw.status >= 500:
+		level = slog.LevelError
+	case rw.status >= 400:
+		level = slog.LevelWarn
+	}
+	if !logger.l.Handler().Enabled(ctx, level) {
+		return
+	}
+
+	args := []any{
+		"method", r.Method,
+		"path", r.URL.Path,
+		"status", rw.status,
+		"duration", duration,
+		"bytes", rw.bytes,
+		"remote_ip", remoteIP(r),
+	}
+	if !logger.requestID {
+		args = append(args, "request_id", id)
+	}
+
+	// Report the line as coming from this function rather than from net/http,
+	// which called the handler.
+	var pcs [1]uintptr
+	runtime.Callers(1, pcs[:])
+	logger.logPC(ctx, pcs[0], level, "handled request", args...)
+}
+
+// responseWriter records the status and number of bytes written.
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(p []byte) (int, error) {
+	w.wroteHeader = true
+	n, err := w.ResponseWriter.Write(p)
+	w.bytes += n
+	return n, err
+}
+
+// Flush implements the [http.Flusher] interface so that handlers that stream
+// responses, such as server-sent events, work through Middleware. It does
+// nothing if the underlying writer doesn't support flushing.
+func (w *responseWriter) Flush() {
+	w.wroteHeader = true
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack implements the [http.Hijacker] interface so that handlers that take
+// over the connection, such as WebSocket servers, work through Middleware.
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// Unwrap allows [http.ResponseController] to access the underlying writer.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	crand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,54 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+)
+
+// LevelFromEnv returns the log level named by the environment variable key,
+// e.g.:
+//
+//	level, err := log.LevelFromEnv("LOG_LEVEL")
+//	if err != nil {
+//		return err
+//	}
+//	logger := log.New(log.WithLevel(level))
+//
+// The value is one of DEBUG, INFO, WARN, or ERROR in any case, optionally
+// followed by an offset such as "ERROR+4", as accepted by
+// [slog.Level.UnmarshalText]. If the variable is unset or empty, LevelFromEnv
+// returns slog.LevelInfo. If the value is invalid, LevelFromEnv returns
+// slog.LevelInfo along with an error, so callers that prefer to continue with
+// the default can ignore it.
+func LevelFromEnv(key string) (slog.Level, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return slog.LevelInfo, nil
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(value)); err != nil {
+		return slog.LevelInfo, fmt.Errorf("log: level from env: %s: %w", key, err)
+	}
+	return level, nil
+}
+
+// levelHandler filters out records below a minimum level before passing them
+// to its handler.
+type levelHandler struct {
+	slog.Handler
+	level slog.Leveler
+}
+
+func (h levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level() && h.Handler.Enabled(ctx, level)
+}
+
+func (h levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return levelHandler{h.Handler.WithAttrs(attrs), h.level}
+}
+
+func (h levelHandler) WithGroup(name string) slog.Handler {
+	return levelHandler{h.Handler.WithGroup(name), h.level}
+}
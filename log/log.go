@@ -2,72 +2,374 @@
 // separated by newlines and containing, at minimum, a timestamp, severity, and
 // message. Log records can be further decorated with additional attributes
 // supplied as key-value pairs.
+//
+// JSON is intended for production use. Other formats that are easier to read
+// in a terminal can be selected with the WithFormat option.
 package log
 
 import (
 	"context"
+	"errors"
 	"io"
 	"log/slog"
 	"os"
+	"runtime"
+	"time"
 )
 
 type config struct {
-	freezeTime bool
-	level      slog.Level
-	output     io.Writer
+	async           *asyncConfig
+	contextAttrs    []func(context.Context) []slog.Attr
+	dedup           *dedupState
+	exit            func(code int)
+	exitCode        int
+	format          Format
+	clock           func() time.Time
+	gcpProject      string
+	handler         slog.Handler
+	hooks           []Hook
+	level           slog.Level
+	middleware      []HandlerMiddleware
+	namedLevels     map[string]slog.Level
+	output          io.Writer
+	redactedKeys    map[string]bool
+	requestID       bool
+	sampling        *sampling
+	source          bool
+	sourceSkip      int
+	stackTrace      bool
+	stackTraceLevel slog.Level
+	syslog          *Syslog
+	timeFormat      string
+	utc             bool
 }
 
 // A Logger records structured information about each call to its Debug, Info,
-// and Error methods.
+// Warn, Error, and Fatal methods.
 //
 // To create a new logger, call New with any desired Options.
 type Logger struct {
-	l *slog.Logger
+	l        *slog.Logger
+	async    *asyncWriter
+	sampling *sampling
+	dedup    *dedupState
+	level    *slog.LevelVar
+	name     string
+	named    *namedLevels
+	skip     int
+	clock    func() time.Time
+
+	// requestID is true if the request ID in the context is added to every
+	// log line.
+	requestID bool
+
+	exit     func(code int)
+	exitCode int
 }
 
 // New creates a new logger that outputs log lines as one-line-per-object
-// JSON.
+// JSON unless another format is selected with WithFormat.
 func New(opts ...Option) *Logger {
 	cfg := config{
-		freezeTime: false,
-		level:      slog.LevelInfo,
-		output:     os.Stderr,
+		async:           nil,
+		contextAttrs:    nil,
+		format:          JSON,
+		clock:           time.Now,
+		dedup:           nil,
+		exit:            os.Exit,
+		exitCode:        1,
+		gcpProject:      "",
+		handler:         nil,
+		hooks:           nil,
+		level:           slog.LevelInfo,
+		middleware:      nil,
+		namedLevels:     nil,
+		output:          os.Stderr,
+		redactedKeys:    nil,
+		requestID:       false,
+		sampling:        nil,
+		source:          false,
+		sourceSkip:      0,
+		stackTrace:      false,
+		stackTraceLevel: slog.LevelError,
+		syslog:          nil,
+		timeFormat:      "",
+		utc:             false,
 	}
 	for _, opt := range opts {
 		opt(&cfg)
 	}
 
+	if cfg.syslog != nil {
+		cfg.output = &syslogConn{network: cfg.syslog.Network, addr: cfg.syslog.Addr}
+	}
+
+	var async *asyncWriter
+	if cfg.async != nil {
+		async = newAsyncWriter(cfg.output, cfg.async)
+		cfg.output = async
+	}
+
+	level := new(slog.LevelVar)
+	level.Set(cfg.level)
+
+	var replacers []func(groups []string, a slog.Attr) slog.Attr
+	if cfg.timeFormat != "" || cfg.utc {
+		replacers = append(replacers, timeReplaceAttr(cfg.timeFormat, cfg.utc))
+	}
+	switch cfg.format {
+	case GCP:
+		replacers = append(replacers, gcpReplaceAttr(cfg.gcpProject))
+	case ECS:
+		replacers = append(replacers, ecsReplaceAttr)
+	}
+	replacers = append(replacers, levelReplaceAttr)
+
+	handlerOpts := &slog.HandlerOptions{
+		AddSource: cfg.source,
+		Level:     levelAll,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			for _, replace := range replacers {
+				a = replace(groups, a)
+			}
+			return a
+		},
+	}
+
+	format := cfg.format
+	if format == Auto {
+		format = JSON
+		if isTerminal(cfg.output) {
+			format = Pretty
+		}
+	}
+
+	output := cfg.output
+	var framer *syslogFramer
+	if cfg.syslog != nil {
+		framer = newSyslogFramer(cfg.syslog, cfg.output)
+		output = framer
+	}
+
+	var handler slog.Handler
+	switch {
+	case cfg.handler != nil:
+		handler = cfg.handler
+	case format == Pretty:
+		handler = newPrettyHandler(output, handlerOpts, useColor(output))
+	case format == Text:
+		handler = slog.NewTextHandler(output, handlerOpts)
+	default:
+		handler = slog.NewJSONHandler(output, handlerOpts)
+	}
+	if format == ECS && cfg.handler == nil {
+		handler = ecsHandler{handler.WithAttrs([]slog.Attr{slog.String("ecs.version", ecsVersion)})}
+	}
+	if framer != nil && cfg.handler == nil {
+		handler = syslogHandler{handler, framer}
+	}
+	if len(cfg.hooks) > 0 {
+		handler = hookHandler{handler, cfg.hooks}
+	}
+	if cfg.sampling != nil {
+		handler = samplingHandler{handler, cfg.sampling}
+	}
+	if cfg.dedup != nil {
+		handler = dedupHandler{handler, cfg.dedup}
+	}
+	for i := len(cfg.middleware) - 1; i >= 0; i-- {
+		handler = cfg.middleware[i](handler)
+	}
+	if len(cfg.redactedKeys) > 0 {
+		handler = redactHandler{handler, cfg.redactedKeys}
+	}
+	// Correlation IDs are added after the bound attributes so that they're
+	// always written at the top level, even by loggers with groups.
+	if cfg.requestID {
+		handler = contextHandler{handler, requestIDAttrs}
+	}
+	for i := len(cfg.contextAttrs) - 1; i >= 0; i-- {
+		handler = contextHandler{handler, cfg.contextAttrs[i]}
+	}
+	handler = newBoundHandler(handler, &cfg)
+
+	// Levels are checked outside all of the other handlers so that named
+	// loggers can have their own levels.
+	named := &namedLevels{levels: cfg.namedLevels}
+	handler = levelHandler{handler, loggerLevel{global: level, named: named}}
+
 	logger := &Logger{
-		l: slog.New(slog.NewJSONHandler(
-			cfg.output,
-			&slog.HandlerOptions{
-				Level: cfg.level,
-				ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
-					if a.Key == slog.TimeKey && cfg.freezeTime {
-						a.Value = slog.StringValue("2024-02-01T12:01:32-05:00")
-					}
-					return a
-				},
-			},
-		)),
+		l:        slog.New(handler),
+		async:    async,
+		sampling: cfg.sampling,
+		dedup:    cfg.dedup,
+		level:    level,
+		named:    named,
+		skip:     cfg.sourceSkip,
+		clock:    cfg.clock,
+
+		requestID: cfg.requestID,
+
+		exit:     cfg.exit,
+		exitCode: cfg.exitCode,
 	}
 
 	return logger
 }
 
+// Close writes any reports of log lines suppressed by WithSampling and any log
+// lines held by WithDedup, and stops the background writer of an asynchronous
+// logger after it has written all of the buffered lines. Lines logged after
+// Close are written synchronously. Close doesn't close the output. For other
+// loggers, Close does nothing.
+//
+// Since loggers derived from l with With or WithGroup share its output, closing
+// any of them closes them all.
+func (l *Logger) Close() error {
+	var errs []error
+	if l.sampling != nil {
+		errs = append(errs, l.sampling.Flush())
+	}
+	if l.dedup != nil {
+		errs = append(errs, l.dedup.Flush())
+	}
+	if l.async != nil {
+		errs = append(errs, l.async.Close())
+	}
+	return errors.Join(errs...)
+}
+
 // Debug emits a log line at the debug level.
 func (l *Logger) Debug(ctx context.Context, msg string, args ...any) {
-	l.l.DebugContext(ctx, msg, args...)
+	l.log(ctx, slog.LevelDebug, msg, args...)
 }
 
 // Error emits a log line at the error level.
 func (l *Logger) Error(ctx context.Context, msg string, args ...any) {
-	l.l.ErrorContext(ctx, msg, args...)
+	l.log(ctx, slog.LevelError, msg, args...)
+}
+
+// Flush writes any reports of log lines suppressed by WithSampling and any log
+// lines held by WithDedup, and waits until all of the lines logged so far by an
+// asynchronous logger have been written. It returns any errors that occurred
+// writing them. For other loggers, Flush does nothing.
+func (l *Logger) Flush() error {
+	var errs []error
+	if l.sampling != nil {
+		errs = append(errs, l.sampling.Flush())
+	}
+	if l.dedup != nil {
+		errs = append(errs, l.dedup.Flush())
+	}
+	if l.async != nil {
+		errs = append(errs, l.async.Flush())
+	}
+	return errors.Join(errs...)
 }
 
 // Info emits a log line at the info level.
 func (l *Logger) Info(ctx context.Context, msg string, args ...any) {
-	l.l.InfoContext(ctx, msg, args...)
+	l.log(ctx, slog.LevelInfo, msg, args...)
+}
+
+// Level returns the minimum level of messages that l outputs. For named
+// loggers, this takes into account any level set for the name.
+func (l *Logger) Level() slog.Level {
+	return loggerLevel{global: l.level, named: l.named, name: l.name}.Level()
+}
+
+// SetLevel changes the minimum level of messages that l outputs. The change
+// applies to l, the logger it was derived from, and every other logger
+// derived from the same call to New, except for named loggers with their own
+// levels, and takes effect immediately. This allows long-running services to
+// switch to debug output without restarting, e.g.:
+//
+//	sigs := make(chan os.Signal, 1)
+//	signal.Notify(sigs, syscall.SIGUSR1)
+//	go func() {
+//		for range sigs {
+//			logger.SetLevel(slog.LevelDebug)
+//		}
+//	}()
+//
+// SetLevel is safe to call concurrently with logging.
+func (l *Logger) SetLevel(level slog.Level) {
+	l.level.Set(level)
+}
+
+// Slog returns a [slog.Logger] that writes to the same output as l using the
+// same configuration, including any attributes and groups added with With and
+// WithGroup. This allows libraries that accept a *slog.Logger to share l's
+// output without constructing a second logger, e.g.:
+//
+//	client := api.NewClient(api.WithLogger(logger.Slog()))
+//
+// Lines emitted through the returned logger use the clock set with WithClock
+// and include the stack traces added by WithStackTrace, just like those
+// emitted by l.
+func (l *Logger) Slog() *slog.Logger {
+	return l.l
+}
+
+// Warn emits a log line at the warn level. Warnings indicate that something
+// unexpected happened that may need attention, but isn't an error.
+func (l *Logger) Warn(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelWarn, msg, args...)
+}
+
+// With returns a child logger that includes the given attributes in every log
+// line it emits, in addition to any attributes already bound to l. The
+// arguments are interpreted as for the Debug, Info, Warn, and Error methods,
+// e.g.:
+//
+//	logger = logger.With("request_id", id, "user_id", user.ID)
+//	logger.Info(ctx, "fetched profile")
+func (l *Logger) With(args ...any) *Logger {
+	child := *l
+	child.l = l.l.With(args...)
+	return &child
+}
+
+// WithGroup returns a child logger that nests all subsequent attributes,
+// including those passed to With on the child, under a JSON object with the
+// given name, e.g.:
+//
+//	logger.WithGroup("http").Info(ctx, "handled request", "method", "GET", "status", 200)
+//
+// emits a line containing "http":{"method":"GET","status":200}. If name is
+// empty, WithGroup returns l.
+func (l *Logger) WithGroup(name string) *Logger {
+	if name == "" {
+		return l
+	}
+	child := *l
+	child.l = l.l.WithGroup(name)
+	return &child
+}
+
+// log emits a log line at the given level. It must be called directly by the
+// exported logging methods so that the source location is reported correctly.
+func (l *Logger) log(ctx context.Context, level slog.Level, msg string, args ...any) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !l.l.Handler().Enabled(ctx, level) {
+		return
+	}
+
+	// Skip runtime.Callers, this function, and the exported logging method.
+	var pcs [1]uintptr
+	runtime.Callers(3+l.skip, pcs[:])
+	l.logPC(ctx, pcs[0], level, msg, args...)
+}
+
+// logPC emits a log line at the given level that is reported as coming from the
+// code at pc. The caller must have checked that the level is enabled.
+func (l *Logger) logPC(ctx context.Context, pc uintptr, level slog.Level, msg string, args ...any) {
+	// The time and stack trace are added by the boundHandler.
+	r := slog.NewRecord(time.Time{}, level, msg, pc)
+	r.Add(args...)
+	_ = l.l.Handler().Handle(ctx, r)
 }
 
 // An Option modifies the configuration of the Logger created by calling New.
@@ -81,11 +383,100 @@ func Debug() Option {
 	}
 }
 
+// A Format determines how log lines are written.
+type Format int
+
+const (
+	// JSON writes each log line as a JSON object. This is the default.
+	JSON Format = iota
+
+	// Text writes each log line as space-separated key=value pairs in the
+	// logfmt style, which is easier to read in terminals and CI logs.
+	Text
+
+	// Pretty writes each log line as a header containing the time, level, and
+	// message followed by one indented line per attribute. When writing to a
+	// terminal, the output is colorized unless the NO_COLOR environment
+	// variable is set. Pretty is intended for local development only.
+	Pretty
+
+	// GCP writes each log line as a JSON object using the field names that
+	// Google Cloud Logging recognizes, so that severities are parsed correctly
+	// for logs written to stdout on Cloud Run and GKE. The time, level, and
+	// message are written as "timestamp", "severity", and "message", and the
+	// "trace_id" and "span_id" attributes, such as those added by the otellog
+	// package, are written as "logging.googleapis.com/trace" and
+	// "logging.googleapis.com/spanId". Use WithGCPProject to qualify trace
+	// IDs with a project ID.
+	GCP
+
+	// ECS writes each log line as a JSON object conforming to the Elastic
+	// Common Schema, so that logs can be indexed by Elasticsearch without
+	// remapping. The time, level, and message are written as "@timestamp",
+	// "log.level", and "message". A top-level "error" attribute, whether an
+	// error value or the group returned by Err, is written as "error.message"
+	// and "error.type", and a top-level "stack" attribute is written as
+	// "error.stack_trace".
+	ECS
+
+	// Auto selects Pretty when writing to a terminal and JSON otherwise, so
+	// that the same configuration can be used locally and in production.
+	Auto
+)
+
 // FreezeTime configures a logger to output a static timestamp. This option is
-// available for testing to make example output deterministic.
+// available for testing to make example output deterministic. It is
+// equivalent to calling WithClock with a function that always returns
+// 2024-02-01T12:01:32-05:00.
 func FreezeTime() Option {
+	frozen := time.Date(2024, time.February, 1, 12, 1, 32, 0, time.FixedZone("", -5*60*60))
+	return WithClock(func() time.Time { return frozen })
+}
+
+// WithClock configures a logger to use now to get the time of each log line
+// instead of [time.Now]. This allows tests to control the time precisely,
+// including advancing it between log lines, e.g.:
+//
+//	now := time.Date(2024, time.February, 1, 12, 0, 0, 0, time.UTC)
+//	logger := log.New(log.WithClock(func() time.Time { return now }))
+//	logger.Info(ctx, "first")
+//	now = now.Add(time.Minute)
+//	logger.Info(ctx, "second")
+//
+// Time-based behavior such as WithSampling uses the same clock. now must be
+// safe for concurrent use if the logger is.
+func WithClock(now func() time.Time) Option {
+	return func(cfg *config) {
+		cfg.clock = now
+	}
+}
+
+// WithFormat configures a logger to write log lines in the given format.
+func WithFormat(format Format) Option {
+	return func(cfg *config) {
+		cfg.format = format
+	}
+}
+
+// WithHandler configures a logger to pass log lines to h instead of writing
+// them to the output. This is useful for sending logs to destinations that
+// this package doesn't support directly and for capturing logs in tests.
+//
+// The level and any options that add, redact, or filter attributes or lines
+// still apply, but options that control how lines are written, such as
+// WithFormat, WithOutput, and WithTimeFormat, are ignored.
+func WithHandler(h slog.Handler) Option {
+	return func(cfg *config) {
+		cfg.handler = h
+	}
+}
+
+// WithLevel configures a logger to output messages at or above level. For
+// example, passing slog.LevelWarn suppresses debug and info messages while
+// still emitting warnings and errors. The default level is slog.LevelInfo.
+func WithLevel(level slog.Level) Option {
 	return func(cfg *config) {
-		cfg.freezeTime = true
+		cfg.level = level
 	}
 }
 
@@ -95,3 +486,16 @@ func WithOutput(w io.Writer) Option {
 		cfg.output = w
 	}
 }
+
+// WithSource configures a logger to include the location of the code that
+// emitted each log line as the "source" attribute, containing the function,
+// file, and line. By default, this is the location of the call to the logging
+// method. Helper functions that wrap a logger can set skip to the number of
+// additional stack frames to skip so that their callers are reported instead,
+// e.g. a skip of 1 reports the caller of a function that calls Info directly.
+func WithSource(skip int) Option {
+	return func(cfg *config) {
+		cfg.source = true
+		cfg.sourceSkip = skip
+	}
+}
@@ -1,10 +1,25 @@
 package log_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
 
 	"github.com/haleyrc/lib/log"
+	"github.com/haleyrc/lib/log/logtest"
 )
 
 func Example() {
@@ -18,10 +33,998 @@ func Example() {
 	logger.Debug(ctx, "debug msg", "string", "Hello, World!")
 	logger.Error(ctx, "error msg", "string", "Hello, World!")
 	logger.Info(ctx, "info msg", "string", "Hello, World!")
+	logger.Warn(ctx, "warn msg", "string", "Hello, World!")
 
 	// Output:
 	//
 	// {"time":"2024-02-01T12:01:32-05:00","level":"DEBUG","msg":"debug msg","string":"Hello, World!"}
 	// {"time":"2024-02-01T12:01:32-05:00","level":"ERROR","msg":"error msg","string":"Hello, World!"}
 	// {"time":"2024-02-01T12:01:32-05:00","level":"INFO","msg":"info msg","string":"Hello, World!"}
+	// {"time":"2024-02-01T12:01:32-05:00","level":"WARN","msg":"warn msg","string":"Hello, World!"}
+}
+
+func ExampleContextWithAttrs() {
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithOutput(os.Stdout),
+	)
+
+	ctx := log.ContextWithAttrs(context.Background(), "request_id", "abc123")
+	ctx = log.ContextWithAttrs(ctx, "user_id", 42)
+	logger.Info(ctx, "fetched profile", "name", "Bender")
+
+	// Output:
+	//
+	// {"time":"2024-02-01T12:01:32-05:00","level":"INFO","msg":"fetched profile","name":"Bender","request_id":"abc123","user_id":42}
+}
+
+func ExampleErr() {
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithOutput(os.Stdout),
+	)
+
+	err := fmt.Errorf("fetch robot: %w", os.ErrNotExist)
+	logger.Error(context.Background(), "failed", log.Err(err))
+
+	// Output:
+	//
+	// {"time":"2024-02-01T12:01:32-05:00","level":"ERROR","msg":"failed","error":{"message":"fetch robot: file does not exist","type":"*fmt.wrapError","chain":[{"message":"file does not exist","type":"*errors.errorString"}]}}
+}
+
+func ExampleLevelFromEnv() {
+	os.Setenv("LOG_LEVEL", "warn")
+	level, err := log.LevelFromEnv("LOG_LEVEL")
+	fmt.Println(level, err)
+
+	os.Setenv("LOG_LEVEL", "verbose")
+	level, err = log.LevelFromEnv("LOG_LEVEL")
+	fmt.Println(level, err)
+
+	os.Unsetenv("LOG_LEVEL")
+	level, err = log.LevelFromEnv("LOG_LEVEL")
+	fmt.Println(level, err)
+
+	// Output:
+	// WARN <nil>
+	// INFO log: level from env: LOG_LEVEL: slog: level string "verbose": unknown name
+	// INFO <nil>
+}
+
+func ExampleLogger_Fatal() {
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithExitCode(3),
+		log.WithExitFunc(func(code int) { fmt.Println("exit", code) }),
+		log.WithOutput(os.Stdout),
+	)
+
+	logger.Fatal(context.Background(), "failed to open database")
+
+	// Output:
+	//
+	// {"time":"2024-02-01T12:01:32-05:00","level":"FATAL","msg":"failed to open database"}
+	// exit 3
+}
+
+func ExampleLogger_Named() {
+	ctx := context.Background()
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithOutput(os.Stdout),
+		log.WithNamedLevels(map[string]slog.Level{"db": slog.LevelDebug}),
+	)
+	db := logger.Named("db")
+	pool := db.Named("pool")
+	api := logger.Named("api")
+
+	logger.Debug(ctx, "hidden")
+	db.Debug(ctx, "querying users")
+	pool.Debug(ctx, "acquired connection")
+	api.Debug(ctx, "hidden")
+
+	logger.SetNamedLevel("db.pool", slog.LevelInfo)
+	pool.Debug(ctx, "hidden")
+
+	// Output:
+	//
+	// {"time":"2024-02-01T12:01:32-05:00","level":"DEBUG","msg":"querying users","logger":"db"}
+	// {"time":"2024-02-01T12:01:32-05:00","level":"DEBUG","msg":"acquired connection","logger":"db.pool"}
+}
+
+func ExampleLogger_SetLevel() {
+	ctx := context.Background()
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithOutput(os.Stdout),
+	)
+	child := logger.With("component", "db")
+
+	child.Debug(ctx, "hidden")
+	logger.SetLevel(slog.LevelDebug)
+	child.Debug(ctx, "shown")
+
+	// Output:
+	//
+	// {"time":"2024-02-01T12:01:32-05:00","level":"DEBUG","msg":"shown","component":"db"}
+}
+
+func ExampleLogger_Slog() {
+	var buf bytes.Buffer
+	logger := log.New(
+		log.WithOutput(&buf),
+		log.WithRedactedKeys("token"),
+	)
+
+	slogger := logger.With("component", "api").Slog()
+	slogger.Info("calling api", "token", "abc123")
+
+	var record map[string]any
+	json.Unmarshal(buf.Bytes(), &record)
+	fmt.Println(record["msg"], record["component"], record["token"])
+
+	// Output:
+	// calling api api [REDACTED]
+}
+
+func ExampleLogger_With() {
+	ctx := context.Background()
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithOutput(os.Stdout),
+	)
+
+	child := logger.With("request_id", "abc123", "user_id", 42)
+	child.Info(ctx, "fetched profile")
+	child.Info(ctx, "updated profile", "name", "Bender")
+	logger.Info(ctx, "no attributes")
+
+	// Output:
+	//
+	// {"time":"2024-02-01T12:01:32-05:00","level":"INFO","msg":"fetched profile","request_id":"abc123","user_id":42}
+	// {"time":"2024-02-01T12:01:32-05:00","level":"INFO","msg":"updated profile","request_id":"abc123","user_id":42,"name":"Bender"}
+	// {"time":"2024-02-01T12:01:32-05:00","level":"INFO","msg":"no attributes"}
+}
+
+func ExampleLogger_WithGroup() {
+	ctx := context.Background()
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithOutput(os.Stdout),
+	)
+
+	logger.With("request_id", "abc123").WithGroup("http").Info(ctx, "handled request", "method", "GET", "status", 200)
+
+	// Output:
+	//
+	// {"time":"2024-02-01T12:01:32-05:00","level":"INFO","msg":"handled request","request_id":"abc123","http":{"method":"GET","status":200}}
+}
+
+func ExampleMiddleware() {
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithOutput(os.Stdout),
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/robots/{name}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("name") != "bender" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("Bender"))
+	})
+	handler := log.Middleware(logger, log.SkipPaths("/healthz"))(mux)
+
+	for _, path := range []string{"/healthz", "/robots/bender", "/robots/flexo"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set(log.RequestIDHeader, "abc123")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	// Output:
+	//
+	// {"time":"2024-02-01T12:01:32-05:00","level":"INFO","msg":"handled request","method":"GET","path":"/robots/bender","status":200,"duration":0,"bytes":6,"remote_ip":"192.0.2.1","request_id":"abc123"}
+	// {"time":"2024-02-01T12:01:32-05:00","level":"WARN","msg":"handled request","method":"GET","path":"/robots/flexo","status":404,"duration":0,"bytes":19,"remote_ip":"192.0.2.1","request_id":"abc123"}
+}
+
+func ExampleParseNamedLevels() {
+	levels, err := log.ParseNamedLevels("db=debug, http=warn")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(levels["db"], levels["http"])
+
+	_, err = log.ParseNamedLevels("db")
+	fmt.Println(err)
+
+	// Output:
+	//
+	// DEBUG WARN
+	// log: parse named levels: "db" is not of the form name=level
+}
+
+func ExampleRecover() {
+	var buf bytes.Buffer
+	logger := log.New(log.WithOutput(&buf))
+
+	ctx := context.Background()
+	func() {
+		defer log.Recover(ctx, logger)
+		var robots map[string]int
+		robots["bender"] = 22
+	}()
+
+	var record struct {
+		Msg   string `json:"msg"`
+		Panic string `json:"panic"`
+		Stack string `json:"stack"`
+	}
+	json.Unmarshal(buf.Bytes(), &record)
+	caller, _, _ := strings.Cut(record.Stack, "\n")
+	fmt.Println(record.Msg)
+	fmt.Println(record.Panic)
+	fmt.Println(caller)
+
+	// Output:
+	// recovered from panic
+	// assignment to entry in nil map
+	// github.com/haleyrc/lib/log_test.ExampleRecover.func1
+}
+
+func ExampleWithAsync() {
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithAsync(16, log.Block),
+		log.WithOutput(os.Stdout),
+	)
+	defer logger.Close()
+
+	ctx := context.Background()
+	logger.Info(ctx, "first")
+	logger.Info(ctx, "second")
+	logger.Flush()
+
+	fmt.Println("flushed")
+
+	// Output:
+	//
+	// {"time":"2024-02-01T12:01:32-05:00","level":"INFO","msg":"first"}
+	// {"time":"2024-02-01T12:01:32-05:00","level":"INFO","msg":"second"}
+	// flushed
+}
+
+func ExampleWithClock() {
+	now := time.Date(2024, time.February, 1, 12, 0, 0, 0, time.UTC)
+	logger := log.New(
+		log.WithClock(func() time.Time { return now }),
+		log.WithOutput(os.Stdout),
+	)
+
+	ctx := context.Background()
+	logger.Info(ctx, "first")
+	now = now.Add(1500 * time.Millisecond)
+	logger.Info(ctx, "second")
+
+	// Output:
+	//
+	// {"time":"2024-02-01T12:00:00Z","level":"INFO","msg":"first"}
+	// {"time":"2024-02-01T12:00:01.5Z","level":"INFO","msg":"second"}
+}
+
+func ExampleWithContextAttrsFunc() {
+	type tenantKey struct{}
+
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithOutput(os.Stdout),
+		log.WithContextAttrsFunc(func(ctx context.Context) []slog.Attr {
+			if tenant, ok := ctx.Value(tenantKey{}).(string); ok {
+				return []slog.Attr{slog.String("tenant", tenant)}
+			}
+			return nil
+		}),
+	)
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "planet-express")
+	logger.Info(ctx, "fetched profile")
+
+	// Output:
+	//
+	// {"time":"2024-02-01T12:01:32-05:00","level":"INFO","msg":"fetched profile","tenant":"planet-express"}
+}
+
+func ExampleWithDedup() {
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithDedup(time.Minute),
+		log.WithOutput(os.Stdout),
+	)
+
+	ctx := context.Background()
+	for range 3 {
+		logger.Error(ctx, "query failed", "table", "robots")
+	}
+	logger.Info(ctx, "connected")
+	logger.Flush()
+
+	// Output:
+	//
+	// {"time":"2024-02-01T12:01:32-05:00","level":"ERROR","msg":"query failed","table":"robots","repeat_count":3}
+	// {"time":"2024-02-01T12:01:32-05:00","level":"INFO","msg":"connected"}
+}
+
+func ExampleWithFile() {
+	dir, _ := os.MkdirTemp("", "logs")
+	defer os.RemoveAll(dir)
+
+	f := log.NewRotatingFile(filepath.Join(dir, "app.log"), log.Rotation{
+		MaxSize:    100,
+		MaxBackups: 2,
+	})
+	defer f.Close()
+
+	logger := log.New(log.FreezeTime(), log.WithOutput(f))
+	for i := range 5 {
+		logger.Info(context.Background(), "info msg", "i", i)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	fmt.Println(len(entries), "files")
+	current, _ := os.ReadFile(filepath.Join(dir, "app.log"))
+	fmt.Print(string(current))
+
+	// Output:
+	// 3 files
+	// {"time":"2024-02-01T12:01:32-05:00","level":"INFO","msg":"info msg","i":4}
+}
+
+func ExampleWithFormat() {
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithFormat(log.Text),
+		log.WithOutput(os.Stdout),
+	)
+
+	ctx := context.Background()
+	logger.With("request_id", "abc123").Info(ctx, "fetched profile", "name", "Bender Rodríguez")
+
+	// Output:
+	//
+	// time=2024-02-01T12:01:32.000-05:00 level=INFO msg="fetched profile" request_id=abc123 name="Bender Rodríguez"
+}
+
+func ExampleWithFormat_ecs() {
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithFormat(log.ECS),
+		log.WithOutput(os.Stdout),
+	)
+
+	ctx := context.Background()
+	err := errors.New("connection refused")
+	logger.Error(ctx, "query failed", "error", err, "retry_error", err)
+	logger.Error(ctx, "query failed", log.Err(fmt.Errorf("query: %w", err)))
+
+	// Output:
+	//
+	// {"@timestamp":"2024-02-01T12:01:32-05:00","log.level":"error","message":"query failed","ecs.version":"8.11.0","error.message":"connection refused","error.type":"*errors.errorString","retry_error":"connection refused"}
+	// {"@timestamp":"2024-02-01T12:01:32-05:00","log.level":"error","message":"query failed","ecs.version":"8.11.0","error.message":"query: connection refused","error.type":"*fmt.wrapError","error.chain":[{"message":"connection refused","type":"*errors.errorString"}]}
+}
+
+func ExampleWithFormat_gcp() {
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithFormat(log.GCP),
+		log.WithGCPProject("planet-express"),
+		log.WithOutput(os.Stdout),
+	)
+
+	ctx := log.ContextWithAttrs(context.Background(), "trace_id", "4bf92f3577b34da6a3ce929d0e0e4736")
+	logger.Warn(ctx, "low on fuel", "remaining", 0.1)
+
+	// Output:
+	//
+	// {"timestamp":"2024-02-01T12:01:32-05:00","severity":"WARNING","message":"low on fuel","remaining":0.1,"logging.googleapis.com/trace":"projects/planet-express/traces/4bf92f3577b34da6a3ce929d0e0e4736"}
+}
+
+func ExampleWithFormat_pretty() {
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithFormat(log.Pretty),
+		log.WithOutput(os.Stdout),
+	)
+
+	ctx := context.Background()
+	logger.With("request_id", "abc123").WithGroup("http").Warn(ctx, "slow request", "method", "GET", "duration", 1500*time.Millisecond)
+
+	// Output:
+	//
+	// 12:01:32.000 WARN  slow request
+	//     request_id:    abc123
+	//     http.method:   GET
+	//     http.duration: 1.5s
+}
+
+func ExampleWithHook() {
+	var errCount int
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithOutput(os.Stdout),
+		log.WithHook(func(ctx context.Context, r *slog.Record) {
+			if r.Level >= slog.LevelError {
+				errCount++
+			}
+			r.AddAttrs(slog.String("service", "robots"))
+		}),
+	)
+
+	ctx := context.Background()
+	logger.Info(ctx, "info msg")
+	logger.Error(ctx, "error msg")
+	fmt.Println(errCount, "error")
+
+	// Output:
+	//
+	// {"time":"2024-02-01T12:01:32-05:00","level":"INFO","msg":"info msg","service":"robots"}
+	// {"time":"2024-02-01T12:01:32-05:00","level":"ERROR","msg":"error msg","service":"robots"}
+	// 1 error
+}
+
+func ExampleWithLevel() {
+	ctx := context.Background()
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithLevel(slog.LevelWarn),
+		log.WithOutput(os.Stdout),
+	)
+
+	logger.Info(ctx, "info msg")
+	logger.Warn(ctx, "warn msg")
+	logger.Error(ctx, "error msg")
+
+	// Output:
+	//
+	// {"time":"2024-02-01T12:01:32-05:00","level":"WARN","msg":"warn msg"}
+	// {"time":"2024-02-01T12:01:32-05:00","level":"ERROR","msg":"error msg"}
+}
+
+// dropDebugHandler is a handler middleware used by ExampleWithMiddleware that
+// drops records whose message starts with "debug:".
+type dropDebugHandler struct {
+	slog.Handler
+}
+
+func (h dropDebugHandler) Handle(ctx context.Context, r slog.Record) error {
+	if strings.HasPrefix(r.Message, "debug:") {
+		return nil
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h dropDebugHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return dropDebugHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h dropDebugHandler) WithGroup(name string) slog.Handler {
+	return dropDebugHandler{h.Handler.WithGroup(name)}
+}
+
+func ExampleWithMetrics() {
+	metrics := log.NewMetrics()
+	logger := log.New(
+		log.WithMetrics(metrics),
+		log.WithOutput(io.Discard),
+		log.WithSampling(1, time.Minute),
+	)
+
+	ctx := context.Background()
+	logger.Debug(ctx, "not counted")
+	logger.Info(ctx, "info msg")
+	for range 3 {
+		logger.Error(ctx, "query failed")
+	}
+
+	fmt.Println(metrics.Count(slog.LevelError))
+	fmt.Println(metrics)
+
+	// Output:
+	// 3
+	// {"DEBUG":0,"ERROR":3,"INFO":1,"WARN":0}
+}
+
+func ExampleWithMiddleware() {
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithOutput(os.Stdout),
+		log.WithMiddleware(func(next slog.Handler) slog.Handler {
+			return dropDebugHandler{next}
+		}),
+	)
+
+	ctx := context.Background()
+	logger.Info(ctx, "debug: cache miss")
+	logger.With("component", "db").Info(ctx, "connected")
+
+	// Output:
+	//
+	// {"time":"2024-02-01T12:01:32-05:00","level":"INFO","msg":"connected","component":"db"}
+}
+
+func ExampleWithOutputs() {
+	var buf bytes.Buffer
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithOutputs(os.Stdout, &buf),
+	)
+
+	logger.Info(context.Background(), "info msg")
+	fmt.Print(buf.String())
+
+	// Output:
+	//
+	// {"time":"2024-02-01T12:01:32-05:00","level":"INFO","msg":"info msg"}
+	// {"time":"2024-02-01T12:01:32-05:00","level":"INFO","msg":"info msg"}
+}
+
+func ExampleWithRedactedKeys() {
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithOutput(os.Stdout),
+		log.WithRedactedKeys("password", "authorization"),
+	)
+
+	logger.Info(context.Background(), "logging in",
+		"user", "bender",
+		"password", "bite-my-shiny-metal",
+		slog.Group("headers", "Authorization", "Bearer abc123"),
+	)
+
+	// Output:
+	//
+	// {"time":"2024-02-01T12:01:32-05:00","level":"INFO","msg":"logging in","user":"bender","password":"[REDACTED]","headers":{"Authorization":"[REDACTED]"}}
+}
+
+func ExampleWithRequestID() {
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithOutput(os.Stdout),
+		log.WithRequestID(),
+	)
+
+	ctx := context.Background()
+	logger.Info(ctx, "no request")
+
+	ctx = log.ContextWithRequestID(ctx, "abc123")
+	logger.Info(ctx, "fetched profile")
+
+	// Output:
+	//
+	// {"time":"2024-02-01T12:01:32-05:00","level":"INFO","msg":"no request"}
+	// {"time":"2024-02-01T12:01:32-05:00","level":"INFO","msg":"fetched profile","request_id":"abc123"}
+}
+
+func ExampleWithRequestID_group() {
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithFormat(log.GCP),
+		log.WithOutput(os.Stdout),
+		log.WithRequestID(),
+		log.WithContextAttrsFunc(func(ctx context.Context) []slog.Attr {
+			return []slog.Attr{slog.String("trace_id", "4bf92f3577b34da6a3ce929d0e0e4736")}
+		}),
+	)
+
+	ctx := log.ContextWithRequestID(context.Background(), "abc123")
+	logger.WithGroup("http").Info(ctx, "handled request", "method", "GET")
+
+	// Output:
+	//
+	// {"timestamp":"2024-02-01T12:01:32-05:00","severity":"INFO","message":"handled request","http":{"method":"GET"},"logging.googleapis.com/trace":"4bf92f3577b34da6a3ce929d0e0e4736","request_id":"abc123"}
+}
+
+func ExampleWithSampling() {
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithOutput(os.Stdout),
+		log.WithSampling(2, time.Minute),
+	)
+
+	ctx := context.Background()
+	for i := range 5 {
+		logger.Error(ctx, "query failed", "attempt", i)
+	}
+	logger.Error(ctx, "connection lost")
+
+	// Output:
+	//
+	// {"time":"2024-02-01T12:01:32-05:00","level":"ERROR","msg":"query failed","attempt":0}
+	// {"time":"2024-02-01T12:01:32-05:00","level":"ERROR","msg":"query failed","attempt":1}
+	// {"time":"2024-02-01T12:01:32-05:00","level":"ERROR","msg":"connection lost"}
+}
+
+func ExampleWithSource() {
+	var buf bytes.Buffer
+	logger := log.New(
+		log.WithOutput(&buf),
+		log.WithSource(1),
+	)
+
+	// Because of the skip, lines logged by logStart are attributed to its
+	// caller.
+	logStart := func(ctx context.Context) {
+		logger.Info(ctx, "starting")
+	}
+	logStart(context.Background())
+
+	var record struct {
+		Source struct {
+			Function string `json:"function"`
+			File     string `json:"file"`
+		} `json:"source"`
+	}
+	json.Unmarshal(buf.Bytes(), &record)
+	fmt.Println(record.Source.Function, filepath.Base(record.Source.File))
+
+	// Output:
+	// github.com/haleyrc/lib/log_test.ExampleWithSource log_test.go
+}
+
+func ExampleWithStackTrace() {
+	var buf bytes.Buffer
+	logger := log.New(
+		log.WithOutput(&buf),
+		log.WithStackTrace(slog.LevelError),
+	)
+
+	ctx := context.Background()
+	logger.Warn(ctx, "low on fuel")
+	logger.Error(ctx, "out of fuel")
+
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var record struct {
+			Msg   string `json:"msg"`
+			Stack string `json:"stack"`
+		}
+		dec.Decode(&record)
+		caller, _, _ := strings.Cut(record.Stack, "\n")
+		fmt.Printf("%s: %q\n", record.Msg, caller)
+	}
+
+	// Output:
+	// low on fuel: ""
+	// out of fuel: "github.com/haleyrc/lib/log_test.ExampleWithStackTrace"
+}
+
+func ExampleWithSyslog() {
+	server, _ := net.ListenPacket("udp", "127.0.0.1:0")
+	defer server.Close()
+
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithSyslog(log.Syslog{
+			Network:  "udp",
+			Addr:     server.LocalAddr().String(),
+			AppName:  "robots",
+			Hostname: "planet-express",
+		}),
+	)
+	logger.Warn(context.Background(), "low on fuel", "remaining", 0.1)
+
+	buf := make([]byte, 1024)
+	n, _, _ := server.ReadFrom(buf)
+
+	// The timestamp and process ID vary, so we skip them here.
+	fields := strings.SplitN(string(buf[:n]), " ", 8)
+	fmt.Println(fields[0], fields[2], fields[3], fields[5], fields[6])
+	fmt.Println(fields[7])
+
+	// Output:
+	// <12>1 planet-express robots - -
+	// {"time":"2024-02-01T12:01:32-05:00","level":"WARN","msg":"low on fuel","remaining":0.1}
+}
+
+func ExampleWithTimeFormat() {
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	logger := log.New(
+		log.WithOutput(&buf),
+		log.WithTimeFormat(log.EpochMillis),
+	)
+	logger.Info(ctx, "info msg")
+
+	var record map[string]any
+	json.Unmarshal(buf.Bytes(), &record)
+	fmt.Printf("%T\n", record["time"])
+
+	buf.Reset()
+	logger = log.New(
+		log.WithOutput(&buf),
+		log.WithTimeFormat(time.RFC3339Nano),
+		log.WithUTC(),
+	)
+	logger.Info(ctx, "info msg")
+
+	record = nil
+	json.Unmarshal(buf.Bytes(), &record)
+	ts, _ := record["time"].(string)
+	fmt.Println(strings.HasSuffix(ts, "Z"))
+
+	// Output:
+	// float64
+	// true
+}
+
+// gateWriter blocks each write until it is released.
+type gateWriter struct {
+	started chan struct{}
+	release chan struct{}
+
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func newGateWriter() *gateWriter {
+	return &gateWriter{started: make(chan struct{}, 16), release: make(chan struct{})}
+}
+
+func (w *gateWriter) Write(p []byte) (int, error) {
+	w.started <- struct{}{}
+	<-w.release
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *gateWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+func TestLogger_Fatal_asyncDrop(t *testing.T) {
+	w := newGateWriter()
+	exited := make(chan int, 1)
+	logger := log.New(
+		log.WithAsync(1, log.Drop),
+		log.WithExitFunc(func(code int) { exited <- code }),
+		log.WithOutput(w),
+	)
+
+	ctx := context.Background()
+	logger.Info(ctx, "first")
+	<-w.started
+	logger.Info(ctx, "second")
+
+	// The buffer is full, so give Fatal a chance to drop the line before the
+	// output catches up.
+	go logger.Fatal(ctx, "failed to open database")
+	time.Sleep(10 * time.Millisecond)
+	close(w.release)
+	if code := <-exited; code != 1 {
+		t.Errorf("Expected exit code to be 1, but got %d.", code)
+	}
+	if !strings.Contains(w.String(), "failed to open database") {
+		t.Errorf("Expected fatal line to be written, but got:\n%s", w.String())
+	}
+}
+
+func TestRecover_withStackTrace(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New(log.WithOutput(&buf), log.WithStackTrace(slog.LevelError))
+
+	func() {
+		defer log.Recover(context.Background(), logger)
+		panic("boom")
+	}()
+
+	if n := strings.Count(buf.String(), `"stack":`); n != 1 {
+		t.Errorf("Expected line to have 1 stack, but got %d:\n%s", n, buf.String())
+	}
+}
+
+func TestMiddleware_panic(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New(log.WithOutput(&buf))
+	handler := log.Middleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	func() {
+		defer func() { recover() }()
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}()
+
+	if !strings.Contains(buf.String(), `"status":500`) {
+		t.Errorf("Expected request to be logged with status 500, but got:\n%s", buf.String())
+	}
+}
+
+func TestMiddleware_responseWriter(t *testing.T) {
+	logger := log.New(log.WithOutput(io.Discard))
+	handler := log.Middleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := w.(http.Flusher); !ok {
+			t.Error("Expected response writer to implement http.Flusher, but it didn't.")
+		}
+		if _, ok := w.(http.Hijacker); !ok {
+			t.Error("Expected response writer to implement http.Hijacker, but it didn't.")
+		}
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestMiddleware_source(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New(log.WithOutput(&buf), log.WithSource(0))
+	handler := log.Middleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	var record struct {
+		Source struct {
+			File string `json:"file"`
+		} `json:"source"`
+	}
+	json.Unmarshal(buf.Bytes(), &record)
+	if filepath.Base(record.Source.File) != "http.go" {
+		t.Errorf("Expected source to be in http.go, but got %q.", record.Source.File)
+	}
+}
+
+func TestLogger_Slog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithOutput(&buf),
+		log.WithStackTrace(slog.LevelError),
+	)
+	logger.WithGroup("db").Slog().Error("query failed")
+
+	var record struct {
+		Time  string `json:"time"`
+		Stack string `json:"stack"`
+	}
+	json.Unmarshal(buf.Bytes(), &record)
+	if want := "2024-02-01T12:01:32-05:00"; record.Time != want {
+		t.Errorf("Expected time to be %q, but got %q.", want, record.Time)
+	}
+	caller, _, _ := strings.Cut(record.Stack, "\n")
+	if want := "github.com/haleyrc/lib/log_test.TestLogger_Slog"; caller != want {
+		t.Errorf("Expected stack to start at %q, but got %q.", want, caller)
+	}
+}
+
+func TestWithHook_redacted(t *testing.T) {
+	var got []string
+	logger := log.New(
+		log.WithOutput(io.Discard),
+		log.WithRedactedKeys("token"),
+		log.WithHook(func(ctx context.Context, r *slog.Record) {
+			r.Attrs(func(a slog.Attr) bool {
+				got = append(got, a.String())
+				return true
+			})
+		}),
+	)
+	logger.With("token", "abc123").WithGroup("auth").Info(context.Background(), "logged in", "token", "def456")
+
+	want := "[token=[REDACTED] auth=[token=[REDACTED]]]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("Expected hook to see %s, but got %s.", want, got)
+	}
+}
+
+func TestWithMiddleware_order(t *testing.T) {
+	capture := logtest.NewCapture()
+	logger := log.New(
+		log.WithOutput(io.Discard),
+		log.WithRequestID(),
+		log.WithRedactedKeys("token"),
+		log.WithMiddleware(func(next slog.Handler) slog.Handler { return capture }),
+	)
+	ctx := log.ContextWithRequestID(context.Background(), "abc123")
+	logger.With("user_id", 42).Info(ctx, "logged in", "token", "def456")
+
+	if !capture.Has("logged in", "request_id", "abc123", "user_id", int64(42), "token", "[REDACTED]") {
+		t.Errorf("Expected middleware to see the complete, redacted record, but got %v.", capture.Records())
+	}
+}
+
+func TestWithDedup_boundAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithDedup(time.Minute),
+		log.WithOutput(&buf),
+		log.WithRequestID(),
+	)
+
+	ctx := context.Background()
+	logger.With("user", 1).Error(ctx, "query failed")
+	logger.With("user", 1).Error(ctx, "query failed")
+	logger.With("user", 2).Error(ctx, "query failed")
+	logger.WithGroup("db").Error(ctx, "query failed", "table", "robots")
+	logger.Error(ctx, "query failed", "table", "robots")
+	logger.Error(log.ContextWithRequestID(ctx, "abc123"), "query failed", "table", "robots")
+	logger.Flush()
+
+	want := `{"time":"2024-02-01T12:01:32-05:00","level":"ERROR","msg":"query failed","user":1,"repeat_count":2}
+{"time":"2024-02-01T12:01:32-05:00","level":"ERROR","msg":"query failed","user":2}
+{"time":"2024-02-01T12:01:32-05:00","level":"ERROR","msg":"query failed","db":{"table":"robots"}}
+{"time":"2024-02-01T12:01:32-05:00","level":"ERROR","msg":"query failed","table":"robots"}
+{"time":"2024-02-01T12:01:32-05:00","level":"ERROR","msg":"query failed","table":"robots","request_id":"abc123"}
+`
+	if buf.String() != want {
+		t.Errorf("Expected output to be:\n%s\nbut got:\n%s", want, buf.String())
+	}
+}
+
+func TestLogger_Named_group(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New(log.FreezeTime(), log.WithOutput(&buf))
+	logger.WithGroup("x").Named("db").With("table", "robots").Named("pool").Info(context.Background(), "acquired connection", "id", 1)
+
+	want := `{"time":"2024-02-01T12:01:32-05:00","level":"INFO","msg":"acquired connection","logger":"db.pool","x":{"table":"robots","id":1}}` + "\n"
+	if buf.String() != want {
+		t.Errorf("Expected output to be:\n%s\nbut got:\n%s", want, buf.String())
+	}
+}
+
+func TestWithSampling_flush(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithOutput(&buf),
+		log.WithSampling(1, time.Hour),
+	)
+
+	ctx := context.Background()
+	for range 100 {
+		logger.Error(ctx, "boom")
+	}
+	logger.Warn(ctx, "slow query")
+	logger.Warn(ctx, "slow query")
+	if err := logger.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"time":"2024-02-01T12:01:32-05:00","level":"ERROR","msg":"boom"}
+{"time":"2024-02-01T12:01:32-05:00","level":"WARN","msg":"slow query"}
+{"time":"2024-02-01T12:01:32-05:00","level":"WARN","msg":"suppressed 1 similar records","sampled_msg":"slow query"}
+{"time":"2024-02-01T12:01:32-05:00","level":"ERROR","msg":"suppressed 99 similar records","sampled_msg":"boom"}
+`
+	if buf.String() != want {
+		t.Errorf("Expected output to be:\n%s\nbut got:\n%s", want, buf.String())
+	}
+}
+
+func TestWithSampling_intervalEnds(t *testing.T) {
+	now := time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	logger := log.New(
+		log.WithClock(func() time.Time { return now }),
+		log.WithOutput(&buf),
+		log.WithSampling(1, time.Minute),
+	)
+
+	ctx := context.Background()
+	for range 3 {
+		logger.Error(ctx, "boom")
+	}
+	now = now.Add(time.Minute)
+	logger.Info(ctx, "recovered")
+	logger.Flush()
+
+	want := `{"time":"2024-02-01T12:00:00Z","level":"ERROR","msg":"boom"}
+{"time":"2024-02-01T12:00:00Z","level":"ERROR","msg":"suppressed 2 similar records","sampled_msg":"boom"}
+{"time":"2024-02-01T12:01:00Z","level":"INFO","msg":"recovered"}
+`
+	if buf.String() != want {
+		t.Errorf("Expected output to be:\n%s\nbut got:\n%s", want, buf.String())
+	}
 }
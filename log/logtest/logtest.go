@@ -0,0 +1,192 @@
+// Package logtest provides utilities for testing code that logs using the log
+// package. It is separate from log so that test helpers aren't included in
+// production builds.
+package logtest
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/haleyrc/lib/log"
+)
+
+// A Record is a log line captured by a Capture.
+type Record struct {
+	Time    time.Time
+	Level   slog.Level
+	Message string
+
+	// Attrs contains the attributes of the line, including those added with
+	// With and from the context. Attributes in groups are flattened into
+	// dotted keys, e.g. "http.method". Values are represented as returned by
+	// [slog.Value.Any], so integers are int64 and so on.
+	Attrs map[string]any
+}
+
+// A Capture records log lines in memory so that tests can make assertions
+// about them without parsing output, e.g.:
+//
+//	capture := logtest.NewCapture()
+//	svc := NewService(log.New(capture.Option()))
+//	svc.DoThing(ctx)
+//	if !capture.Has("did thing", "user_id", 42) {
+//		t.Error("expected thing to be logged")
+//	}
+//
+// A Capture is an [slog.Handler], so it can also be used with [log.WithHandler]
+// directly. A Capture is safe for concurrent use.
+type Capture struct {
+	store *store
+
+	// attrs have already had groups applied to their keys.
+	attrs  []slog.Attr
+	groups []string
+}
+
+type store struct {
+	mu      sync.Mutex
+	records []Record
+}
+
+// NewCapture creates a new, empty Capture.
+func NewCapture() *Capture {
+	return &Capture{store: &store{}}
+}
+
+// Option returns an option that configures a logger to send its log lines to
+// c.
+func (c *Capture) Option() log.Option {
+	return log.WithHandler(c)
+}
+
+// Filter returns the captured records at the given level.
+func (c *Capture) Filter(level slog.Level) []Record {
+	var records []Record
+	for _, r := range c.Records() {
+		if r.Level == level {
+			records = append(records, r)
+		}
+	}
+	return records
+}
+
+// Has reports whether a record was captured with the given message and
+// attributes. The attributes are given as alternating keys and values, and
+// the record may have additional attributes that aren't listed. Keys of
+// attributes in groups are dotted, e.g.:
+//
+//	capture.Has("handled request", "http.status", 200)
+func (c *Capture) Has(msg string, args ...any) bool {
+	want := make(map[string]any, len(args)/2)
+	for i := 0; i+1 < len(args); i += 2 {
+		key, _ := args[i].(string)
+		want[key] = slog.AnyValue(args[i+1]).Resolve().Any()
+	}
+
+	for _, r := range c.Records() {
+		if r.Message != msg {
+			continue
+		}
+		matches := true
+		for key, value := range want {
+			got, ok := r.Attrs[key]
+			if !ok || !reflect.DeepEqual(got, value) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// Records returns all of the captured records in the order they were logged.
+func (c *Capture) Records() []Record {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+	records := make([]Record, len(c.store.records))
+	copy(records, c.store.records)
+	return records
+}
+
+// Reset discards all of the captured records.
+func (c *Capture) Reset() {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+	c.store.records = nil
+}
+
+// Enabled implements the slog.Handler interface. It always returns true since
+// level filtering is handled by the logger.
+func (c *Capture) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+// Handle implements the slog.Handler interface.
+func (c *Capture) Handle(_ context.Context, r slog.Record) error {
+	attrs := make(map[string]any, len(c.attrs)+r.NumAttrs())
+	for _, a := range c.attrs {
+		attrs[a.Key] = a.Value.Any()
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		for _, fa := range flatten(nil, c.groups, a) {
+			attrs[fa.Key] = fa.Value.Any()
+		}
+		return true
+	})
+
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+	c.store.records = append(c.store.records, Record{
+		Time:    r.Time,
+		Level:   r.Level,
+		Message: r.Message,
+		Attrs:   attrs,
+	})
+	return nil
+}
+
+// WithAttrs implements the slog.Handler interface.
+func (c *Capture) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c2 := *c
+	c2.attrs = append([]slog.Attr{}, c.attrs...)
+	for _, a := range attrs {
+		c2.attrs = flatten(c2.attrs, c.groups, a)
+	}
+	return &c2
+}
+
+// WithGroup implements the slog.Handler interface.
+func (c *Capture) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return c
+	}
+	c2 := *c
+	c2.groups = append(append([]string{}, c.groups...), name)
+	return &c2
+}
+
+// flatten appends a to attrs, flattening groups into dotted keys.
+func flatten(attrs []slog.Attr, groups []string, a slog.Attr) []slog.Attr {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			groups = append(append([]string{}, groups...), a.Key)
+		}
+		for _, ga := range a.Value.Group() {
+			attrs = flatten(attrs, groups, ga)
+		}
+		return attrs
+	}
+	if a.Equal(slog.Attr{}) {
+		return attrs
+	}
+	a.Key = strings.Join(append(append([]string{}, groups...), a.Key), ".")
+	return append(attrs, a)
+}
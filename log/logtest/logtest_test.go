@@ -0,0 +1,36 @@
+package logtest_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/haleyrc/lib/log"
+	"github.com/haleyrc/lib/log/logtest"
+)
+
+func ExampleCapture() {
+	capture := logtest.NewCapture()
+	logger := log.New(capture.Option())
+
+	ctx := context.Background()
+	logger.With("user_id", 42).Info(ctx, "fetched profile")
+	logger.WithGroup("http").Warn(ctx, "slow request", "status", 200)
+	logger.Debug(ctx, "not captured")
+
+	for _, r := range capture.Records() {
+		fmt.Println(r.Level, r.Message, r.Attrs)
+	}
+	fmt.Println(len(capture.Filter(slog.LevelWarn)))
+	fmt.Println(capture.Has("fetched profile", "user_id", 42))
+	fmt.Println(capture.Has("slow request", "http.status", 200))
+	fmt.Println(capture.Has("slow request", "http.status", 500))
+
+	// Output:
+	// INFO fetched profile map[user_id:42]
+	// WARN slow request map[http.status:200]
+	// 1
+	// true
+	// true
+	// false
+}
@@ -0,0 +1,96 @@
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+)
+
+// Metrics counts the log lines emitted at each level. The counts only ever
+// increase, so they can be published as counters and used to alert on rates
+// such as errors per minute. A Metrics implements [expvar.Var], so it can be
+// published directly, e.g.:
+//
+//	metrics := log.NewMetrics()
+//	expvar.Publish("log", metrics)
+//	logger := log.New(log.WithMetrics(metrics))
+//
+// For other metrics systems, Snapshot can be polled by a collector. A Metrics
+// is safe for concurrent use.
+type Metrics struct {
+	mu     sync.Mutex
+	counts map[slog.Level]uint64
+}
+
+// NewMetrics creates a new Metrics with all counts at zero.
+func NewMetrics() *Metrics {
+	return &Metrics{counts: make(map[slog.Level]uint64)}
+}
+
+// Count returns the number of log lines emitted at level.
+func (m *Metrics) Count(level slog.Level) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.counts[level]
+}
+
+// Snapshot returns the number of log lines emitted at each level, keyed by the
+// name of the level as written in log lines, e.g. "ERROR". The standard levels
+// are always included, even if their count is zero.
+func (m *Metrics) Snapshot() map[string]uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	snapshot := map[string]uint64{
+		levelString(slog.LevelDebug): 0,
+		levelString(slog.LevelInfo):  0,
+		levelString(slog.LevelWarn):  0,
+		levelString(slog.LevelError): 0,
+	}
+	for level, n := range m.counts {
+		snapshot[levelString(level)] += n
+	}
+	return snapshot
+}
+
+// String returns the snapshot of m as a JSON object, implementing the
+// expvar.Var interface.
+func (m *Metrics) String() string {
+	b, _ := json.Marshal(m.Snapshot())
+	return string(b)
+}
+
+func (m *Metrics) add(level slog.Level) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counts[level]++
+}
+
+// WithMetrics configures a logger to count the lines it emits in m. Lines are
+// counted before sampling and deduplication, so m reflects the number of
+// calls to the logging methods at or above the logger's level rather than the
+// number of lines written.
+func WithMetrics(m *Metrics) Option {
+	return WithMiddleware(func(next slog.Handler) slog.Handler {
+		return metricsHandler{next, m}
+	})
+}
+
+// metricsHandler counts each record in its metrics.
+type metricsHandler struct {
+	slog.Handler
+	metrics *Metrics
+}
+
+func (h metricsHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.metrics.add(r.Level)
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h metricsHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return metricsHandler{h.Handler.WithAttrs(attrs), h.metrics}
+}
+
+func (h metricsHandler) WithGroup(name string) slog.Handler {
+	return metricsHandler{h.Handler.WithGroup(name), h.metrics}
+}
@@ -0,0 +1,33 @@
+package log
+
+import "log/slog"
+
+// A HandlerMiddleware wraps an [slog.Handler] to add behavior such as
+// filtering, enriching, or rewriting records. Each record passed to the
+// handler already includes every attribute of the log line, including those
+// bound with With and WithGroup, but implementations must still wrap the
+// handlers returned by WithAttrs and WithGroup to satisfy the slog.Handler
+// contract.
+type HandlerMiddleware func(next slog.Handler) slog.Handler
+
+// WithMiddleware configures a logger to pass each record through the given
+// middleware. The first middleware is outermost, so it sees each record first,
+// e.g.:
+//
+//	logger := log.New(log.WithMiddleware(enrich, filter))
+//
+// passes each record to enrich, which passes it to filter, which passes it on
+// to be written. Calling WithMiddleware multiple times appends to the list.
+//
+// Middleware sits at a fixed point among the handlers that implement the other
+// options. Records reach the middleware after the level has been checked, the
+// attributes from With, WithGroup, and the context have been added, and the
+// keys set with WithRedactedKeys have been redacted. Records leaving the
+// middleware are then sampled by WithSampling, collapsed by WithDedup, passed
+// to the hooks set with WithHook, and written. The built-in options can't be
+// reordered relative to the middleware.
+func WithMiddleware(mw ...HandlerMiddleware) Option {
+	return func(cfg *config) {
+		cfg.middleware = append(cfg.middleware, mw...)
+	}
+}
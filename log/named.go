@@ -0,0 +1,169 @@
+package log
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"sync"
+)
+
+// levelAll is a level below every other level. It is used as the level of the
+// underlying handlers since levels are checked by a levelHandler.
+const levelAll = slog.Level(math.MinInt)
+
+// Named returns a child logger for the component with the given name, e.g.:
+//
+//	dbLogger := logger.Named("db")
+//
+// Every line emitted by the child includes the name as the top-level "logger"
+// attribute, even if the child or l has groups.
+// Calling Named on a named logger appends to its name with a dot, so
+// logger.Named("db").Named("pool") is named "db.pool".
+//
+// Named loggers can be given their own levels with WithNamedLevels or
+// SetNamedLevel, so that one component can be debugged without enabling debug
+// output for everything else. A named logger uses the level set for the
+// longest matching prefix of its name, so a level set for "db" also applies to
+// "db.pool" unless "db.pool" has its own level. Loggers without a matching
+// level use the logger's level.
+func (l *Logger) Named(name string) *Logger {
+	if l.name != "" {
+		name = l.name + "." + name
+	}
+
+	child := *l
+	child.name = name
+
+	// Loggers created by New check their level in a levelHandler wrapping a
+	// boundHandler. Fall back to adding the name as an ordinary attribute for
+	// anything else.
+	lh, ok := l.l.Handler().(levelHandler)
+	bh, bound := lh.Handler.(*boundHandler)
+	if !ok || !bound {
+		child.l = l.l.With("logger", name)
+		return &child
+	}
+	child.l = slog.New(levelHandler{
+		Handler: bh.withName(name),
+		level:   loggerLevel{global: l.level, named: l.named, name: name},
+	})
+	return &child
+}
+
+// SetNamedLevel sets the minimum level of messages output by loggers with the
+// given name, and those whose names start with name followed by a dot, that
+// were derived from the same call to New as l. The change takes effect
+// immediately.
+func (l *Logger) SetNamedLevel(name string, level slog.Level) {
+	l.named.set(name, level)
+}
+
+// ClearNamedLevel removes the level set for loggers with the given name, so
+// that they use the level of the closest matching name or the logger's level
+// instead.
+func (l *Logger) ClearNamedLevel(name string) {
+	l.named.clear(name)
+}
+
+// WithNamedLevels configures the levels of named loggers created with Named.
+// The keys are names and the values are the minimum levels of messages output
+// by loggers with those names, e.g.:
+//
+//	logger := log.New(log.WithNamedLevels(map[string]slog.Level{
+//		"db":   slog.LevelDebug,
+//		"http": slog.LevelInfo,
+//	}))
+//
+// Use ParseNamedLevels to read the levels from a string.
+func WithNamedLevels(levels map[string]slog.Level) Option {
+	return func(cfg *config) {
+		if cfg.namedLevels == nil {
+			cfg.namedLevels = make(map[string]slog.Level, len(levels))
+		}
+		for name, level := range levels {
+			cfg.namedLevels[name] = level
+		}
+	}
+}
+
+// ParseNamedLevels parses a comma-separated list of name=level pairs, such as
+// "db=debug,http=info", for use with WithNamedLevels. Levels are parsed in the
+// same way as LevelFromEnv. This makes it easy to configure named levels from
+// the environment, e.g.:
+//
+//	levels, err := log.ParseNamedLevels(os.Getenv("LOG_LEVELS"))
+func ParseNamedLevels(s string) (map[string]slog.Level, error) {
+	levels := make(map[string]slog.Level)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("log: parse named levels: %q is not of the form name=level", pair)
+		}
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(strings.TrimSpace(value))); err != nil {
+			return nil, fmt.Errorf("log: parse named levels: %s: %w", name, err)
+		}
+		levels[strings.TrimSpace(name)] = level
+	}
+	return levels, nil
+}
+
+// namedLevels holds the levels of named loggers. It is shared by all of the
+// loggers derived from the same call to New.
+type namedLevels struct {
+	mu     sync.RWMutex
+	levels map[string]slog.Level
+}
+
+// lookup returns the level for the longest matching prefix of name.
+func (n *namedLevels) lookup(name string) (slog.Level, bool) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	for name != "" {
+		if level, ok := n.levels[name]; ok {
+			return level, true
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return 0, false
+}
+
+func (n *namedLevels) set(name string, level slog.Level) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.levels == nil {
+		n.levels = make(map[string]slog.Level)
+	}
+	n.levels[name] = level
+}
+
+func (n *namedLevels) clear(name string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.levels, name)
+}
+
+// loggerLevel is the level of a logger, which depends on its name.
+type loggerLevel struct {
+	global *slog.LevelVar
+	named  *namedLevels
+	name   string
+}
+
+func (l loggerLevel) Level() slog.Level {
+	if l.name != "" {
+		if level, ok := l.named.lookup(l.name); ok {
+			return level
+		}
+	}
+	return l.global.Level()
+}
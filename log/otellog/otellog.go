@@ -0,0 +1,39 @@
+// Package otellog correlates log lines with OpenTelemetry traces by adding the
+// IDs of the active span to each line, so that logs can be joined with traces
+// in tools such as Grafana Tempo. This lives in a separate package from log so
+// that projects that don't use OpenTelemetry don't need to depend on it.
+package otellog
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/haleyrc/lib/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithTrace configures a logger to include the "trace_id" and "span_id" of the
+// span stored in the context, if any, in every log line, e.g.:
+//
+//	logger := log.New(otellog.WithTrace())
+//
+//	ctx, span := tracer.Start(ctx, "fetch profile")
+//	defer span.End()
+//	logger.Info(ctx, "fetched profile")
+//
+// Lines emitted with a context that doesn't carry a valid span context are
+// unaffected.
+func WithTrace() log.Option {
+	return log.WithContextAttrsFunc(traceAttrs)
+}
+
+func traceAttrs(ctx context.Context) []slog.Attr {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return []slog.Attr{
+		slog.String("trace_id", sc.TraceID().String()),
+		slog.String("span_id", sc.SpanID().String()),
+	}
+}
@@ -0,0 +1,33 @@
+package otellog_test
+
+import (
+	"context"
+	"os"
+
+	"github.com/haleyrc/lib/log"
+	"github.com/haleyrc/lib/log/otellog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func ExampleWithTrace() {
+	logger := log.New(
+		log.FreezeTime(),
+		log.WithOutput(os.Stdout),
+		otellog.WithTrace(),
+	)
+
+	ctx := context.Background()
+	logger.Info(ctx, "no span")
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:  trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+	})
+	ctx = trace.ContextWithSpanContext(ctx, sc)
+	logger.Info(ctx, "fetched profile")
+
+	// Output:
+	//
+	// {"time":"2024-02-01T12:01:32-05:00","level":"INFO","msg":"no span"}
+	// {"time":"2024-02-01T12:01:32-05:00","level":"INFO","msg":"fetched profile","trace_id":"4bf92f3577b34da6a3ce929d0e0e4736","span_id":"00f067aa0ba902b7"}
+}
@@ -0,0 +1,36 @@
+package log
+
+import (
+	"errors"
+	"io"
+)
+
+// WithOutputs configures a logger to write every log line to each of ws, e.g.
+// to both stderr and a file:
+//
+//	logger := log.New(log.WithOutputs(os.Stderr, f))
+//
+// Unlike with [io.MultiWriter], a failed write to one output doesn't prevent
+// the line from being written to the others.
+func WithOutputs(ws ...io.Writer) Option {
+	return func(cfg *config) {
+		cfg.output = teeWriter(ws)
+	}
+}
+
+// teeWriter writes to every writer, even if some of them fail.
+type teeWriter []io.Writer
+
+func (t teeWriter) Write(p []byte) (int, error) {
+	var errs []error
+	for _, w := range t {
+		n, err := w.Write(p)
+		if err == nil && n < len(p) {
+			err = io.ErrShortWrite
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return len(p), errors.Join(errs...)
+}
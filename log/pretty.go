@@ -0,0 +1,185 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiFaint  = "\x1b[2m"
+	ansiRed    = "\x1b[31m"
+	ansiYellow = "\x1b[33m"
+	ansiCyan   = "\x1b[36m"
+	ansiGray   = "\x1b[90m"
+)
+
+// prettyHandler writes records over multiple lines for reading in a terminal
+// during development. The first line contains the time, level, and message,
+// and is followed by one indented line per attribute with the values aligned.
+// Attributes in groups are flattened into dotted keys.
+type prettyHandler struct {
+	opts  slog.HandlerOptions
+	color bool
+
+	// attrs have already had groups applied to their keys.
+	attrs  []slog.Attr
+	groups []string
+
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+func newPrettyHandler(w io.Writer, opts *slog.HandlerOptions, color bool) *prettyHandler {
+	h := &prettyHandler{color: color, mu: new(sync.Mutex), w: w}
+	if opts != nil {
+		h.opts = *opts
+	}
+	return h
+}
+
+func (h *prettyHandler) Enabled(_ context.Context, level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.opts.Level != nil {
+		minLevel = h.opts.Level.Level()
+	}
+	return level >= minLevel
+}
+
+func (h *prettyHandler) Handle(_ context.Context, r slog.Record) error {
+	var buf bytes.Buffer
+
+	if !r.Time.IsZero() {
+		timeAttr := h.replace(nil, slog.Time(slog.TimeKey, r.Time))
+		if !timeAttr.Equal(slog.Attr{}) {
+			ts := timeAttr.Value.String()
+			if timeAttr.Value.Kind() == slog.KindTime {
+				ts = timeAttr.Value.Time().Format("15:04:05.000")
+			}
+			buf.WriteString(h.paint(ansiGray, ts))
+			buf.WriteByte(' ')
+		}
+	}
+	buf.WriteString(h.paint(levelColor(r.Level), fmt.Sprintf("%-5s", levelString(r.Level))))
+	buf.WriteByte(' ')
+	buf.WriteString(h.paint(ansiBold, r.Message))
+	buf.WriteByte('\n')
+
+	attrs := append([]slog.Attr{}, h.attrs...)
+	if h.opts.AddSource && r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		attrs = append(attrs, slog.String(slog.SourceKey, fmt.Sprintf("%s:%d", frame.File, frame.Line)))
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = h.appendAttr(attrs, h.groups, a)
+		return true
+	})
+
+	width := 0
+	for _, a := range attrs {
+		width = max(width, len(a.Key))
+	}
+	for _, a := range attrs {
+		key := a.Key + ":" + strings.Repeat(" ", width-len(a.Key))
+		value := strings.ReplaceAll(a.Value.String(), "\n", "\n    "+strings.Repeat(" ", width+2))
+		fmt.Fprintf(&buf, "    %s %s\n", h.paint(ansiFaint, key), value)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.w.Write(buf.Bytes())
+	return err
+}
+
+func (h *prettyHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.attrs = append([]slog.Attr{}, h.attrs...)
+	for _, a := range attrs {
+		h2.attrs = h2.appendAttr(h2.attrs, h.groups, a)
+	}
+	return &h2
+}
+
+func (h *prettyHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.groups = append(append([]string{}, h.groups...), name)
+	return &h2
+}
+
+// appendAttr appends a to attrs, flattening groups into dotted keys.
+func (h *prettyHandler) appendAttr(attrs []slog.Attr, groups []string, a slog.Attr) []slog.Attr {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			groups = append(append([]string{}, groups...), a.Key)
+		}
+		for _, ga := range a.Value.Group() {
+			attrs = h.appendAttr(attrs, groups, ga)
+		}
+		return attrs
+	}
+	a = h.replace(groups, a)
+	if a.Equal(slog.Attr{}) {
+		return attrs
+	}
+	a.Key = strings.Join(append(append([]string{}, groups...), a.Key), ".")
+	return append(attrs, a)
+}
+
+func (h *prettyHandler) replace(groups []string, a slog.Attr) slog.Attr {
+	if h.opts.ReplaceAttr == nil {
+		return a
+	}
+	return h.opts.ReplaceAttr(groups, a)
+}
+
+func (h *prettyHandler) paint(color, s string) string {
+	if !h.color {
+		return s
+	}
+	return color + s + ansiReset
+}
+
+func levelColor(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return ansiRed
+	case level >= slog.LevelWarn:
+		return ansiYellow
+	case level >= slog.LevelInfo:
+		return ansiCyan
+	default:
+		return ansiGray
+	}
+}
+
+// isTerminal reports whether w is a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// useColor reports whether colorized output should be written to w. Colors
+// are only used for terminals and can be disabled by setting the NO_COLOR
+// environment variable.
+func useColor(w io.Writer) bool {
+	return isTerminal(w) && os.Getenv("NO_COLOR") == ""
+}
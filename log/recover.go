@@ -0,0 +1,64 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// A RecoverOption modifies the behavior of Recover.
+type RecoverOption func(*recoverConfig)
+
+type recoverConfig struct {
+	repanic bool
+}
+
+// Repanic configures Recover to panic again with the same value after logging
+// it, so that the panic still crashes the program or is handled further up
+// the stack.
+func Repanic() RecoverOption {
+	return func(cfg *recoverConfig) {
+		cfg.repanic = true
+	}
+}
+
+// Recover recovers from a panic and logs the panic value at the error level
+// along with a stack trace of the code that panicked. It must be deferred
+// directly, e.g.:
+//
+//	go func() {
+//		defer log.Recover(ctx, logger)
+//		work(ctx)
+//	}()
+//
+// Since [recover] only stops a panic when it is called by the deferred
+// function itself, calling Recover from within a deferred function literal,
+// as in defer func() { log.Recover(ctx, logger) }(), does nothing.
+//
+// If there is no panic, Recover does nothing. Panics with
+// [http.ErrAbortHandler], which net/http uses to abort a response, are always
+// repanicked without being logged.
+func Recover(ctx context.Context, logger *Logger, opts ...RecoverOption) {
+	v := recover()
+	if v == nil {
+		return
+	}
+	if v == http.ErrAbortHandler {
+		panic(v)
+	}
+
+	var cfg recoverConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	args := []any{"panic", fmt.Sprint(v), "stack", stackTrace(1)}
+	if err, ok := v.(error); ok {
+		args = append(args, Err(err))
+	}
+	logger.Error(ctx, "recovered from panic", args...)
+
+	if cfg.repanic {
+		panic(v)
+	}
+}
@@ -0,0 +1,80 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// redacted replaces the values of attributes with redacted keys.
+const redacted = "[REDACTED]"
+
+// WithRedactedKeys configures a logger to replace the values of attributes
+// with any of the given keys with "[REDACTED]" before they are written, e.g.:
+//
+//	logger := log.New(log.WithRedactedKeys("password", "token", "authorization"))
+//
+// Keys are matched case-insensitively and at any depth, so "Authorization"
+// inside a "headers" group is also redacted. Values are redacted before they
+// are passed to hooks, middleware, or a handler set with WithHandler. Calling
+// WithRedactedKeys multiple times adds to the set of redacted keys.
+func WithRedactedKeys(keys ...string) Option {
+	return func(cfg *config) {
+		if cfg.redactedKeys == nil {
+			cfg.redactedKeys = make(map[string]bool, len(keys))
+		}
+		for _, key := range keys {
+			cfg.redactedKeys[strings.ToLower(key)] = true
+		}
+	}
+}
+
+// redactHandler redacts the values of attributes with the given lowercase keys
+// before passing records to its handler. Redacting records rather than using
+// ReplaceAttr means that hooks, middleware, and handlers set with WithHandler
+// never see the original values.
+type redactHandler struct {
+	slog.Handler
+	keys map[string]bool
+}
+
+func (h redactHandler) Handle(ctx context.Context, r slog.Record) error {
+	r2 := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		r2.AddAttrs(h.redact(a))
+		return true
+	})
+	return h.Handler.Handle(ctx, r2)
+}
+
+func (h redactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = h.redact(a)
+	}
+	return redactHandler{h.Handler.WithAttrs(redacted), h.keys}
+}
+
+func (h redactHandler) WithGroup(name string) slog.Handler {
+	return redactHandler{h.Handler.WithGroup(name), h.keys}
+}
+
+// redact returns a with its value redacted if its key is redacted, or with
+// the attributes in it redacted if it is a group.
+func (h redactHandler) redact(a slog.Attr) slog.Attr {
+	if h.keys[strings.ToLower(a.Key)] {
+		a.Value = slog.StringValue(redacted)
+		return a
+	}
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() != slog.KindGroup {
+		return a
+	}
+	group := a.Value.Group()
+	attrs := make([]slog.Attr, len(group))
+	for i, ga := range group {
+		attrs[i] = h.redact(ga)
+	}
+	a.Value = slog.GroupValue(attrs...)
+	return a
+}
@@ -0,0 +1,44 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+)
+
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx that carries the given request
+// ID. Loggers created with the WithRequestID option include it as the
+// "request_id" attribute of every line emitted with the returned context.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx by
+// ContextWithRequestID, if any.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+// WithRequestID configures a logger to include the request ID stored in the
+// context by ContextWithRequestID as the "request_id" attribute of every log
+// line. The request ID is always written at the top level of the log line, even
+// by loggers with groups, so that lines can be correlated by a single field.
+// Lines emitted with a context that doesn't carry a request ID are unaffected.
+func WithRequestID() Option {
+	return func(cfg *config) {
+		cfg.requestID = true
+	}
+}
+
+// requestIDAttrs returns the request ID stored in ctx as an attribute.
+func requestIDAttrs(ctx context.Context) []slog.Attr {
+	if id, ok := RequestIDFromContext(ctx); ok {
+		return []slog.Attr{slog.String("request_id", id)}
+	}
+	return nil
+}
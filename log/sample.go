@@ -0,0 +1,192 @@
+package log
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WithSampling configures a logger to limit how many records with the same
+// level and message are written. Within each interval, the first n such
+// records are written and the rest are dropped. Once the interval ends, a
+// record reporting the number of dropped records is written, e.g.:
+//
+//	{"level":"ERROR","msg":"suppressed 5000 similar records","sampled_msg":"query failed"}
+//
+// The report is written before the next record that is logged after the
+// interval ends, or when Flush, Close, or Fatal is called, whichever comes
+// first.
+//
+// This protects downstream log pipelines during error storms. Attributes
+// aren't considered when deciding whether records are similar.
+func WithSampling(n int, interval time.Duration) Option {
+	return func(cfg *config) {
+		cfg.sampling = &sampling{
+			first:    n,
+			interval: interval,
+			counts:   make(map[sampleKey]*sampleCount),
+		}
+	}
+}
+
+type sampleKey struct {
+	level slog.Level
+	msg   string
+}
+
+type sampleCount struct {
+	start time.Time
+	count int
+
+	// suppressed is the number of records dropped since the last report, and
+	// last is the most recent of them.
+	suppressed int
+	last       suppressedRecord
+}
+
+type suppressedRecord struct {
+	ctx     context.Context
+	handler slog.Handler
+	time    time.Time
+}
+
+// sampleReport is a pending report of suppressed records.
+type sampleReport struct {
+	suppressedRecord
+	key   sampleKey
+	count int
+}
+
+// sampling holds the state shared by a samplingHandler and all of the
+// handlers derived from it.
+type sampling struct {
+	first    int
+	interval time.Duration
+
+	mu     sync.Mutex
+	counts map[sampleKey]*sampleCount
+	swept  time.Time
+}
+
+// Flush writes a report for each level and message with records that were
+// suppressed since the last report.
+func (s *sampling) Flush() error {
+	s.mu.Lock()
+	var reports []sampleReport
+	for key, c := range s.counts {
+		if report, ok := c.report(key); ok {
+			reports = append(reports, report)
+		}
+	}
+	s.mu.Unlock()
+	return writeReports(reports)
+}
+
+// sample reports whether r, which is being passed to h, should be written, and
+// returns the reports for any intervals that have ended.
+func (s *sampling) sample(ctx context.Context, h slog.Handler, r slog.Record) (ok bool, reports []sampleReport) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Remove the counts for intervals that have ended so that they don't
+	// accumulate when there are many different messages.
+	if r.Time.Sub(s.swept) >= s.interval {
+		for key, c := range s.counts {
+			if r.Time.Sub(c.start) < s.interval {
+				continue
+			}
+			if report, ok := c.report(key); ok {
+				reports = append(reports, report)
+			}
+			delete(s.counts, key)
+		}
+		s.swept = r.Time
+	}
+
+	key := sampleKey{level: r.Level, msg: r.Message}
+	c, found := s.counts[key]
+	if !found || r.Time.Sub(c.start) >= s.interval {
+		if found {
+			if report, ok := c.report(key); ok {
+				reports = append(reports, report)
+			}
+		}
+		c = &sampleCount{start: r.Time}
+		s.counts[key] = c
+	}
+
+	c.count++
+	if c.count > s.first {
+		c.suppressed++
+		c.last = suppressedRecord{ctx: ctx, handler: h, time: r.Time}
+		return false, reports
+	}
+	return true, reports
+}
+
+// report returns a report of the records suppressed since the last one, if
+// any, and resets the count. The caller must hold the sampling's mu.
+func (c *sampleCount) report(key sampleKey) (sampleReport, bool) {
+	if c.suppressed == 0 {
+		return sampleReport{}, false
+	}
+	report := sampleReport{suppressedRecord: c.last, key: key, count: c.suppressed}
+	c.suppressed = 0
+	c.last = suppressedRecord{}
+	return report, true
+}
+
+func (r sampleReport) write() error {
+	summary := slog.NewRecord(r.time, r.key.level, fmt.Sprintf("suppressed %d similar records", r.count), 0)
+	summary.AddAttrs(slog.String("sampled_msg", r.key.msg))
+	return r.handler.Handle(r.ctx, summary)
+}
+
+// writeReports writes reports in the order the suppressed records were logged.
+func writeReports(reports []sampleReport) error {
+	slices.SortFunc(reports, func(a, b sampleReport) int {
+		if c := a.time.Compare(b.time); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.key.level, b.key.level); c != 0 {
+			return c
+		}
+		return strings.Compare(a.key.msg, b.key.msg)
+	})
+	var errs []error
+	for _, r := range reports {
+		errs = append(errs, r.write())
+	}
+	return errors.Join(errs...)
+}
+
+// samplingHandler drops records according to its sampling configuration.
+type samplingHandler struct {
+	slog.Handler
+	sampling *sampling
+}
+
+func (h samplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	ok, reports := h.sampling.sample(ctx, h.Handler, r)
+	if err := writeReports(reports); err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return samplingHandler{h.Handler.WithAttrs(attrs), h.sampling}
+}
+
+func (h samplingHandler) WithGroup(name string) slog.Handler {
+	return samplingHandler{h.Handler.WithGroup(name), h.sampling}
+}
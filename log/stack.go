@@ -0,0 +1,81 @@
+package log
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// maxStackDepth is the maximum number of frames included in a stack trace.
+const maxStackDepth = 32
+
+// WithStackTrace configures a logger to include a "stack" attribute containing
+// a stack trace of the call site in every log line at or above level, e.g.:
+//
+//	logger := log.New(log.WithStackTrace(slog.LevelError))
+//
+// Each frame is written as the function name followed by an indented line with
+// the file and line number. Frames inside the Go runtime are omitted and the
+// trace is truncated after 32 frames. Lines that already have a "stack"
+// attribute, such as those logged by Recover, are left as they are.
+func WithStackTrace(level slog.Level) Option {
+	return func(cfg *config) {
+		cfg.stackTrace = true
+		cfg.stackTraceLevel = level
+	}
+}
+
+// stackTrace returns a trimmed stack trace starting skip frames above the
+// caller of stackTrace.
+func stackTrace(skip int) string {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip+2, pcs)
+	return formatStack(pcs[:n])
+}
+
+// stackTraceAt returns a trimmed stack trace starting at the frame containing
+// pc, which must be on the stack of the caller of stackTraceAt. If it isn't,
+// the trace starts at the caller.
+func stackTraceAt(pc uintptr) string {
+	// Leave room for the frames between pc and the caller, such as those of
+	// the logging methods and handlers.
+	pcs := make([]uintptr, 2*maxStackDepth)
+	n := runtime.Callers(2, pcs)
+	pcs = pcs[:n]
+	if i := slices.Index(pcs, pc); i >= 0 {
+		pcs = pcs[i:]
+	}
+	return formatStack(pcs[:min(len(pcs), maxStackDepth)])
+}
+
+// formatStack formats the frames at pcs, omitting those inside the Go runtime.
+func formatStack(pcs []uintptr) string {
+	if len(pcs) == 0 {
+		return ""
+	}
+	frames := runtime.CallersFrames(pcs)
+
+	var b strings.Builder
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "runtime.") {
+			fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		}
+		if !more {
+			break
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// hasAttr reports whether r has a top-level attribute with the given key.
+func hasAttr(r slog.Record, key string) bool {
+	found := false
+	r.Attrs(func(a slog.Attr) bool {
+		found = a.Key == key
+		return !found
+	})
+	return found
+}
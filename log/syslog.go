@@ -0,0 +1,216 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Syslog configures the syslog server that a logger created with WithSyslog
+// writes to.
+type Syslog struct {
+	// Network and Addr identify the syslog server as for [net.Dial], e.g.
+	// "udp" and "logs.example.com:514". If Network is empty, the local syslog
+	// daemon is used.
+	Network string
+	Addr    string
+
+	// Facility is the syslog facility code as defined by RFC 5424. If Facility
+	// is zero, the "user" facility (1) is used since the zero value refers to
+	// kernel messages.
+	Facility int
+
+	// AppName identifies the application. If AppName is empty, the base name
+	// of the executable is used.
+	AppName string
+
+	// Hostname identifies the machine sending the messages. If Hostname is
+	// empty, the name reported by the kernel is used.
+	Hostname string
+}
+
+// WithSyslog configures a logger to send each log line to a syslog server as
+// an RFC 5424 message instead of writing it to the output, e.g.:
+//
+//	logger := log.New(log.WithSyslog(log.Syslog{Network: "udp", Addr: "localhost:514"}))
+//
+// The body of each message is the log line in the format selected by
+// WithFormat. The message severity is derived from the log level, so errors
+// are sent with the "err" severity, warnings with "warning", and so on. The
+// connection is established on the first write and reestablished if a write
+// fails. Messages sent over stream networks such as TCP are framed using
+// octet counting as described by RFC 6587.
+func WithSyslog(cfg Syslog) Option {
+	return func(c *config) {
+		c.syslog = &cfg
+	}
+}
+
+// syslogSeverity maps a log level to a syslog severity.
+func syslogSeverity(level slog.Level) int {
+	switch {
+	case level > slog.LevelError:
+		return 2 // crit
+	case level == slog.LevelError:
+		return 3 // err
+	case level >= slog.LevelWarn:
+		return 4 // warning
+	case level >= slog.LevelInfo:
+		return 6 // info
+	default:
+		return 7 // debug
+	}
+}
+
+// syslogFramer collects the output of a handler for a single record and
+// writes it to the syslog connection with an RFC 5424 header.
+type syslogFramer struct {
+	facility int
+	appName  string
+	hostname string
+	procID   string
+	stream   bool
+
+	// mu is held for the duration of each record so that buf only ever holds
+	// the output for a single record.
+	mu  sync.Mutex
+	buf bytes.Buffer
+	out io.Writer
+}
+
+func newSyslogFramer(cfg *Syslog, out io.Writer) *syslogFramer {
+	f := &syslogFramer{
+		facility: cfg.Facility,
+		appName:  cfg.AppName,
+		hostname: cfg.Hostname,
+		procID:   strconv.Itoa(os.Getpid()),
+		stream:   isStream(cfg.Network),
+		out:      out,
+	}
+	if f.facility == 0 {
+		f.facility = 1
+	}
+	if f.appName == "" {
+		f.appName = filepath.Base(os.Args[0])
+	}
+	if f.hostname == "" {
+		f.hostname, _ = os.Hostname()
+	}
+	if f.hostname == "" {
+		f.hostname = "-"
+	}
+	return f
+}
+
+// Write is called by the wrapped handler while mu is held.
+func (f *syslogFramer) Write(p []byte) (int, error) {
+	return f.buf.Write(p)
+}
+
+func (f *syslogFramer) frame(level slog.Level, t time.Time, msg []byte) []byte {
+	msg = bytes.TrimSuffix(msg, []byte("\n"))
+	timestamp := "-"
+	if !t.IsZero() {
+		timestamp = t.Format("2006-01-02T15:04:05.000000Z07:00")
+	}
+	header := fmt.Sprintf("<%d>1 %s %s %s %s - - ",
+		f.facility*8+syslogSeverity(level),
+		timestamp,
+		f.hostname, f.appName, f.procID,
+	)
+	frame := append([]byte(header), msg...)
+	if f.stream {
+		frame = append([]byte(strconv.Itoa(len(frame))+" "), frame...)
+	}
+	return frame
+}
+
+// syslogHandler frames the output of its handler as syslog messages.
+type syslogHandler struct {
+	slog.Handler
+	framer *syslogFramer
+}
+
+func (h syslogHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.framer.mu.Lock()
+	defer h.framer.mu.Unlock()
+
+	h.framer.buf.Reset()
+	if err := h.Handler.Handle(ctx, r); err != nil {
+		return err
+	}
+	_, err := h.framer.out.Write(h.framer.frame(r.Level, r.Time, h.framer.buf.Bytes()))
+	return err
+}
+
+func (h syslogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return syslogHandler{h.Handler.WithAttrs(attrs), h.framer}
+}
+
+func (h syslogHandler) WithGroup(name string) slog.Handler {
+	return syslogHandler{h.Handler.WithGroup(name), h.framer}
+}
+
+// syslogConn is a connection to a syslog server that is established lazily
+// and reestablished after a failed write.
+type syslogConn struct {
+	network, addr string
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func (c *syslogConn) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		conn, err := dialSyslog(c.network, c.addr)
+		if err != nil {
+			return 0, fmt.Errorf("log: syslog: %w", err)
+		}
+		c.conn = conn
+	}
+	n, err := c.conn.Write(p)
+	if err != nil {
+		c.conn.Close()
+		c.conn = nil
+		return n, fmt.Errorf("log: syslog: %w", err)
+	}
+	return n, nil
+}
+
+// dialSyslog connects to the syslog server at addr, or to the local syslog
+// daemon if network is empty.
+func dialSyslog(network, addr string) (net.Conn, error) {
+	if network != "" {
+		return net.Dial(network, addr)
+	}
+	var errs []error
+	for _, path := range []string{"/dev/log", "/var/run/syslog", "/var/run/log"} {
+		conn, err := net.Dial("unixgram", path)
+		if err == nil {
+			return conn, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, fmt.Errorf("connect to local syslog: %w", errors.Join(errs...))
+}
+
+func isStream(network string) bool {
+	switch network {
+	case "tcp", "tcp4", "tcp6", "unix":
+		return true
+	default:
+		return false
+	}
+}
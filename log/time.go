@@ -0,0 +1,52 @@
+package log
+
+import "log/slog"
+
+// EpochMillis can be passed to WithTimeFormat to write timestamps as the number
+// of milliseconds since the Unix epoch.
+const EpochMillis = "epochmillis"
+
+// WithTimeFormat configures a logger to write timestamps using layout, as
+// accepted by [time.Time.Format], e.g.:
+//
+//	logger := log.New(log.WithTimeFormat(time.RFC3339Nano))
+//
+// If layout is EpochMillis, timestamps are written as integers instead. By
+// default, timestamps are written in the format used by the output format,
+// which is RFC 3339 with millisecond precision for JSON.
+func WithTimeFormat(layout string) Option {
+	return func(cfg *config) {
+		cfg.timeFormat = layout
+	}
+}
+
+// WithUTC configures a logger to write timestamps in UTC instead of the local
+// time zone.
+func WithUTC() Option {
+	return func(cfg *config) {
+		cfg.utc = true
+	}
+}
+
+// timeReplaceAttr returns a ReplaceAttr function that formats the time of
+// each record according to layout, converting it to UTC first if utc is set.
+func timeReplaceAttr(layout string, utc bool) func(groups []string, a slog.Attr) slog.Attr {
+	return func(groups []string, a slog.Attr) slog.Attr {
+		if a.Key != slog.TimeKey || len(groups) > 0 || a.Value.Kind() != slog.KindTime {
+			return a
+		}
+		t := a.Value.Time()
+		if utc {
+			t = t.UTC()
+		}
+		switch layout {
+		case "":
+			a.Value = slog.TimeValue(t)
+		case EpochMillis:
+			a.Value = slog.Int64Value(t.UnixMilli())
+		default:
+			a.Value = slog.StringValue(t.Format(layout))
+		}
+		return a
+	}
+}